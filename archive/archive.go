@@ -0,0 +1,298 @@
+// Package archive bundles the artifacts of an election into a single signed file,
+// which can be opened and re-verified years later.
+//
+// An archive is a zip file holding the artifacts,
+// a MANIFEST listing the SHA-256 digest of each artifact
+// and MANIFEST.sig, the Ed25519 signature of the manifest.
+package archive
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// Names of the manifest and of its signature in the archive.
+const (
+	Manifest  = "MANIFEST"
+	Signature = "MANIFEST.sig"
+)
+
+// Names of the artifacts created by Artifacts.
+const (
+	ConfigFile   = "config.json"
+	BallotsFile  = "ballots.json"
+	SnapshotFile = "snapshot.pb"
+	PairwiseFile = "pairwise.csv"
+	ReportFile   = "report.txt"
+	TraceFile    = "trace.json"
+	HashFile     = "result.hash"
+)
+
+// derived lists the artifacts derived from the snapshot.
+var derived = []string{PairwiseFile, ReportFile, TraceFile, HashFile}
+
+// Artifacts returns the artifacts of a result:
+// the configuration of the election, given by the caller,
+// the anonymized ballots if the profile is stored (see condorcet.Exact),
+// a snapshot of the result in the protobuf format (see condorcet.Result.MarshalProto)
+// and the artifacts derived from it:
+// the pairwise matrix, a text report, the trace of the elimination methods
+// and the fingerprint of the result.
+func Artifacts(config []byte, r condorcet.Result) (map[string][]byte, error) {
+	files, err := derive(r)
+	if err != nil {
+		return nil, err
+	}
+	files[ConfigFile] = config
+
+	var buf bytes.Buffer
+	switch err := r.WriteCVRJSON(&buf); err {
+	case nil:
+		files[BallotsFile] = buf.Bytes()
+	case condorcet.ErrNoProfile:
+	default:
+		return nil, err
+	}
+
+	if files[SnapshotFile], err = r.MarshalProto(); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// derive returns the artifacts derived from the result.
+func derive(r condorcet.Result) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	var buf bytes.Buffer
+	if err := r.WritePairwiseCSV(&buf); err != nil {
+		return nil, err
+	}
+	files[PairwiseFile] = append([]byte(nil), buf.Bytes()...)
+
+	buf.Reset()
+	if err := r.WriteText(&buf); err != nil {
+		return nil, err
+	}
+	files[ReportFile] = append([]byte(nil), buf.Bytes()...)
+
+	trace, err := json.MarshalIndent(traceOf(r), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	files[TraceFile] = append(trace, '\n')
+
+	hash := r.Fingerprint()
+	files[HashFile] = []byte(hex.EncodeToString(hash[:]) + "\n")
+	return files, nil
+}
+
+// methodTrace is the trace of an elimination method.
+type methodTrace struct {
+	Winner *int              `json:"winner"` // nil if the last candidates tie
+	Rounds []condorcet.Round `json:"rounds"`
+}
+
+// traceOf returns the traces of the elimination methods, by name.
+// Methods requiring the ballots are left out if the profile is not stored.
+func traceOf(r condorcet.Result) map[string]methodTrace {
+	traces := make(map[string]methodTrace)
+	add := func(name string, w int, unique bool, rounds []condorcet.Round) {
+		t := methodTrace{Rounds: rounds}
+		if unique {
+			t.Winner = &w
+		}
+		traces[name] = t
+	}
+
+	w, unique, rounds := r.Baldwin()
+	add("baldwin", w, unique, rounds)
+	if w, unique, rounds, err := r.SmithIRV(); err == nil {
+		add("smithirv", w, unique, rounds)
+	}
+	if w, unique, rounds, err := r.Tideman(); err == nil {
+		add("tideman", w, unique, rounds)
+	}
+	return traces
+}
+
+// Write writes the signed archive of the files.
+func Write(w io.Writer, files map[string][]byte, key ed25519.PrivateKey) error {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if name == Manifest || name == Signature || strings.ContainsAny(name, " \n") {
+			return fmt.Errorf("invalid artifact name %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var manifest bytes.Buffer
+	for _, name := range names {
+		sum := sha256.Sum256(files[name])
+		fmt.Fprintf(&manifest, "%s  %s\n", hex.EncodeToString(sum[:]), name)
+	}
+
+	zw := zip.NewWriter(w)
+	add := func(name string, content []byte) error {
+		f, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(content)
+		return err
+	}
+	for _, name := range names {
+		if err := add(name, files[name]); err != nil {
+			return err
+		}
+	}
+	if err := add(Manifest, manifest.Bytes()); err != nil {
+		return err
+	}
+	if err := add(Signature, ed25519.Sign(key, manifest.Bytes())); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// Open reads a signed archive and verifies the signature of the manifest,
+// the digest of every artifact, that the pairwise matrix, report, trace and fingerprint
+// are the ones derived again from the snapshot and, if the ballots are archived,
+// that they tally to the archived pairwise matrix.
+// It returns the artifacts.
+func Open(r io.ReaderAt, size int64, key ed25519.PublicKey) (map[string][]byte, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files[f.Name] = content
+	}
+
+	manifest, sig := files[Manifest], files[Signature]
+	if manifest == nil || sig == nil {
+		return nil, errors.New("missing manifest or signature")
+	}
+	if !ed25519.Verify(key, manifest, sig) {
+		return nil, errors.New("invalid signature")
+	}
+	delete(files, Manifest)
+	delete(files, Signature)
+
+	listed := make(map[string]bool)
+	sc := bufio.NewScanner(bytes.NewReader(manifest))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) != 2 {
+			return nil, errors.New("malformed manifest")
+		}
+		content, ok := files[fields[1]]
+		if !ok {
+			return nil, fmt.Errorf("missing artifact %s", fields[1])
+		}
+		sum := sha256.Sum256(content)
+		if hex.EncodeToString(sum[:]) != fields[0] {
+			return nil, fmt.Errorf("artifact %s does not match its digest", fields[1])
+		}
+		listed[fields[1]] = true
+	}
+	for name := range files {
+		if !listed[name] {
+			return nil, fmt.Errorf("artifact %s is not in the manifest", name)
+		}
+	}
+
+	if err := rederive(files); err != nil {
+		return nil, err
+	}
+	if ballots, ok := files[BallotsFile]; ok {
+		if err := retally(ballots, files[PairwiseFile]); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// rederive checks that the artifacts derived from the snapshot match the archived ones.
+func rederive(files map[string][]byte) error {
+	snapshot, ok := files[SnapshotFile]
+	if !ok {
+		for _, name := range derived {
+			if _, ok := files[name]; ok {
+				return fmt.Errorf("artifact %s without snapshot", name)
+			}
+		}
+		return nil
+	}
+
+	var r condorcet.Result
+	if err := r.UnmarshalProto(snapshot); err != nil {
+		return fmt.Errorf("invalid snapshot: %v", err)
+	}
+	again, err := derive(r)
+	if err != nil {
+		return err
+	}
+	for _, name := range derived {
+		if content, ok := files[name]; ok && !bytes.Equal(content, again[name]) {
+			return fmt.Errorf("artifact %s does not match the snapshot", name)
+		}
+	}
+	return nil
+}
+
+// retally checks that the archived ballots tally to the archived pairwise matrix.
+func retally(ballots, pairwise []byte) error {
+	var doc struct {
+		Candidates int `json:"candidates"`
+		CVRs       []struct {
+			Ranking []int `json:"ranking"`
+		} `json:"cvrs"`
+	}
+	if err := json.Unmarshal(ballots, &doc); err != nil {
+		return fmt.Errorf("invalid ballots: %v", err)
+	}
+
+	e, err := condorcet.New(doc.Candidates)
+	if err != nil {
+		return fmt.Errorf("invalid ballots: %v", err)
+	}
+	for k, cvr := range doc.CVRs {
+		if !e.Vote(cvr.Ranking...) {
+			return fmt.Errorf("invalid ballot %d", k+1)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := e.Result().WritePairwiseCSV(&buf); err != nil {
+		return err
+	}
+	if !bytes.Equal(buf.Bytes(), pairwise) {
+		return errors.New("ballots do not tally to the pairwise matrix")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package archive_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/archive"
+)
+
+// TestArchive writes and re-opens an archive, then tampers with it.
+func TestArchive(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, _ := condorcet.New(3, condorcet.Exact())
+	e.Vote(2, 0, 1)
+	e.Vote(0, 2, 1)
+	e.Vote(2, 1, 0)
+	files, err := archive.Artifacts([]byte(`{"name":"board"}`), e.Result())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{archive.BallotsFile, archive.SnapshotFile, archive.TraceFile} {
+		if _, ok := files[name]; !ok {
+			t.Fatalf("%s is not archived", name)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := archive.Write(&buf, files, priv); err != nil {
+		t.Fatal(err)
+	}
+	opened, err := archive.Open(bytes.NewReader(buf.Bytes()), int64(buf.Len()), pub)
+	if err != nil {
+		t.Fatalf("cannot open the archive: %v", err)
+	}
+	if string(opened[archive.ConfigFile]) != `{"name":"board"}` {
+		t.Errorf("unexpected configuration %q", opened[archive.ConfigFile])
+	}
+
+	// wrong key
+	other, _, _ := ed25519.GenerateKey(nil)
+	if _, err := archive.Open(bytes.NewReader(buf.Bytes()), int64(buf.Len()), other); err == nil {
+		t.Error("archive verified with the wrong key")
+	}
+
+	// derived artifacts inconsistent with the snapshot, signed again
+	for _, name := range []string{archive.PairwiseFile, archive.ReportFile, archive.TraceFile, archive.HashFile} {
+		tampered := copyFiles(files)
+		tampered[name] = append(tampered[name], ' ')
+		if open(t, tampered, priv, pub) == nil {
+			t.Errorf("%s inconsistent with the snapshot verified", name)
+		}
+	}
+
+	// derived artifacts without snapshot
+	tampered := copyFiles(files)
+	delete(tampered, archive.SnapshotFile)
+	if open(t, tampered, priv, pub) == nil {
+		t.Error("report verified without snapshot")
+	}
+
+	// ballots inconsistent with the matrix
+	tampered = copyFiles(files)
+	tampered[archive.BallotsFile] = []byte(`{"candidates":3,"cvrs":[{"ranking":[0,1,2]}]}`)
+	if open(t, tampered, priv, pub) == nil {
+		t.Error("inconsistent ballots verified")
+	}
+}
+
+// open signs the files into an archive and re-opens it.
+func open(t *testing.T, files map[string][]byte, priv ed25519.PrivateKey, pub ed25519.PublicKey) error {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := archive.Write(&buf, files, priv); err != nil {
+		t.Fatal(err)
+	}
+	_, err := archive.Open(bytes.NewReader(buf.Bytes()), int64(buf.Len()), pub)
+	return err
+}
+
+// copyFiles returns a copy of the artifacts.
+func copyFiles(files map[string][]byte) map[string][]byte {
+	c := make(map[string][]byte, len(files))
+	for name, content := range files {
+		c[name] = append([]byte(nil), content...)
+	}
+	return c
+}
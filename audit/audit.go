@@ -0,0 +1,156 @@
+// Package audit implements risk-limiting audits (RLA) of the winner of a Condorcet election.
+//
+// The winner is confirmed if it beats every other candidate in their pairwise contest,
+// so the audit tests one assertion per challenger: the winner beats it.
+// If the reported winner is wrong, at least one assertion is false,
+// and each assertion is tested at the risk limit:
+// the chance that the audit confirms a wrong outcome is at most the risk limit.
+//
+// Ballots must be drawn uniformly at random, with replacement, by the caller,
+// e.g. with a seeded pseudo-random generator published before the draw.
+// Two kinds of audits are supported:
+// ballot-polling audits only read the sampled paper ballots (see Polling),
+// ballot-comparison audits compare them with their cast vote records (see Comparison).
+package audit
+
+import (
+	"errors"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// Status is the state of an audit.
+type Status int
+
+// States of an audit.
+const (
+	Continue    Status = iota // more ballots must be sampled
+	Confirmed                 // the outcome is confirmed at the risk limit
+	FullRecount               // the sample reached its limit: the ballots must be counted by hand
+)
+
+// String implements fmt.Stringer.
+func (s Status) String() string {
+	switch s {
+	case Continue:
+		return "continue"
+	case Confirmed:
+		return "confirmed"
+	case FullRecount:
+		return "full recount"
+	}
+	return "unknown"
+}
+
+// Contest is an assertion of an audit: the winner beats the loser in their pairwise contest.
+type Contest struct {
+	Winner, Loser int
+	For, Against  int     // reported number of voters preferring the winner to the loser, and the loser to the winner
+	Risk          float64 // measured risk of the assertion, in [0, 1]: the contest is confirmed if it is below the risk limit
+	Confirmed     bool
+}
+
+// audit is the state shared by the audits.
+type audit struct {
+	n        int // number of candidates
+	ballots  int // number of ballots
+	risk     float64
+	limit    int
+	sampled  int
+	contests []Contest
+}
+
+// newAudit returns the audit of the winner of the result, with the assertions to test.
+func newAudit(r condorcet.Result, risk float64, limit int) (audit, error) {
+	if !(risk > 0 && risk < 1) {
+		return audit{}, errors.New("risk limit must be in (0, 1)")
+	}
+	if limit < 0 {
+		return audit{}, errors.New("negative sample limit")
+	}
+	if r.NumVoters() != r.NumBallots() {
+		return audit{}, errors.New("weighted ballots cannot be audited")
+	}
+	w, exist := r.Winner()
+	if !exist {
+		return audit{}, errors.New("no winner to audit")
+	}
+	if limit == 0 {
+		limit = r.NumBallots()
+	}
+
+	excluded := make(map[int]bool)
+	for _, x := range r.Exclusions() {
+		excluded[x.Candidate] = true
+	}
+	a := audit{n: r.NumCandidates(), ballots: r.NumBallots(), risk: risk, limit: limit}
+	for c := 0; c < r.NumCandidates(); c++ {
+		if c != w && !excluded[c] {
+			a.contests = append(a.contests, Contest{w, c, r.Pairwise(w, c), r.Pairwise(c, w), 1, false})
+		}
+	}
+	return a, nil
+}
+
+// Contests returns the assertions of the audit, by increasing loser.
+func (a *audit) Contests() []Contest { return append([]Contest(nil), a.contests...) }
+
+// Risk returns the measured risk of the audit: the largest risk of its assertions.
+// The outcome is confirmed when it is below the risk limit.
+func (a *audit) Risk() float64 {
+	var risk float64
+	for _, c := range a.contests {
+		if c.Risk > risk {
+			risk = c.Risk
+		}
+	}
+	return risk
+}
+
+// NumSampled returns the number of sampled ballots.
+func (a *audit) NumSampled() int { return a.sampled }
+
+// Status returns the state of the audit.
+func (a *audit) Status() Status {
+	for _, c := range a.contests {
+		if !c.Confirmed {
+			if a.sampled >= a.limit {
+				return FullRecount
+			}
+			return Continue
+		}
+	}
+	return Confirmed
+}
+
+// preferences returns the positions of the candidates in the ballot:
+// candidates missing from a truncated ballot are tied last.
+// An empty ballot expresses no preference.
+func (a *audit) preferences(ballot []int) ([]int, error) {
+	pos := make([]int, a.n)
+	for c := range pos {
+		pos[c] = len(ballot)
+	}
+	for p, c := range ballot {
+		if c < 0 || c >= a.n {
+			return nil, condorcet.ErrCandidateOutOfRange
+		}
+		if pos[c] != len(ballot) {
+			return nil, condorcet.ErrDuplicateCandidate
+		}
+		pos[c] = p
+	}
+	return pos, nil
+}
+
+// vote returns the contribution of the ballot, given as positions, to the margin of the contest:
+// 1 if it prefers the winner, -1 if it prefers the loser and 0 otherwise.
+func vote(pos []int, c Contest) int {
+	switch {
+	case pos[c.Winner] < pos[c.Loser]:
+		return 1
+	case pos[c.Winner] > pos[c.Loser]:
+		return -1
+	}
+	return 0
+}
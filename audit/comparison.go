@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"math"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// gamma is the error inflation factor of the comparison audits,
+// as recommended by Stark's super-simple audits.
+const gamma = 1.03905
+
+// Comparison is a ballot-comparison audit: a Kaplan-Markov test of every assertion.
+//
+// The diluted margin μ of an assertion is its reported margin divided by the number of ballots.
+// The overstatement of a sampled ballot is the contribution of its cast vote record to the margin
+// minus the contribution of the paper ballot, between -2 and 2.
+// The risk of the assertion is the product over the sampled ballots of (1 - μ/2γ) / (1 - e/2γ),
+// where e is the overstatement and γ an inflation factor of 1.03905.
+type Comparison struct {
+	audit
+	products []float64 // risks of the contests, not capped to 1
+}
+
+// NewComparison returns a ballot-comparison audit of the winner of the result, with the risk limit in (0, 1).
+// The audit escalates to a full recount after limit sampled ballots, 0 for the number of ballots.
+func NewComparison(r condorcet.Result, risk float64, limit int) (*Comparison, error) {
+	a, err := newAudit(r, risk, limit)
+	if err != nil {
+		return nil, err
+	}
+	products := make([]float64, len(a.contests))
+	for k := range products {
+		products[k] = 1
+	}
+	return &Comparison{a, products}, nil
+}
+
+// margin returns the diluted margin of the contest.
+func (a *Comparison) margin(c Contest) float64 {
+	return float64(c.For-c.Against) / float64(a.ballots)
+}
+
+// SampleSize returns the number of ballots to sample to confirm the outcome,
+// if no ballot differs from its cast vote record.
+func (a *Comparison) SampleSize() int {
+	var size int
+	for _, c := range a.contests {
+		n := int(math.Ceil(math.Log(a.risk) / math.Log(1-a.margin(c)/(2*gamma))))
+		if n > size {
+			size = n
+		}
+	}
+	return size
+}
+
+// Compare records a sampled ballot: its cast vote record, as reported by the tally,
+// and the paper ballot, as read by the auditors.
+// Candidates missing from a truncated ballot are tied last; an empty ballot expresses no preference.
+// It returns the status of the audit.
+func (a *Comparison) Compare(reported, actual []int) (Status, error) {
+	rpos, err := a.preferences(reported)
+	if err != nil {
+		return a.Status(), err
+	}
+	apos, err := a.preferences(actual)
+	if err != nil {
+		return a.Status(), err
+	}
+	a.sampled++
+
+	for k, c := range a.contests {
+		if c.Confirmed {
+			continue
+		}
+		e := float64(vote(rpos, c) - vote(apos, c))
+		a.products[k] *= (1 - a.margin(c)/(2*gamma)) / (1 - e/(2*gamma))
+		a.contests[k].Risk = math.Min(1, a.products[k])
+		a.contests[k].Confirmed = a.products[k] <= a.risk
+	}
+	return a.Status(), nil
+}
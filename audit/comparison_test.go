@@ -0,0 +1,48 @@
+package audit_test
+
+import (
+	"testing"
+
+	"github.com/batiazinga/condorcet/audit"
+)
+
+// TestComparison checks the risk of a comparison audit with and without discrepancies.
+func TestComparison(t *testing.T) {
+	r := election(t)
+
+	// diluted margins of 0.2: the risk is multiplied by 1 - 0.2/2.0781 per correct ballot
+	a, err := audit.NewComparison(r, 0.05, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := a.SampleSize(); size != 30 {
+		t.Errorf("sample size is %d instead of 30", size)
+	}
+	for k := 1; k <= 30; k++ {
+		status, err := a.Compare([]int{1, 2, 0}, []int{1, 2, 0})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := audit.Continue
+		if k == 30 {
+			want = audit.Confirmed
+		}
+		if status != want {
+			t.Fatalf("status %v after %d ballots at risk %v", status, k, a.Risk())
+		}
+	}
+
+	// a two-vote overstatement in both contests
+	a, _ = audit.NewComparison(r, 0.05, 0)
+	a.Compare([]int{0, 1, 2}, []int{0, 1, 2})
+	low := a.Risk()
+	a.Compare([]int{0, 1, 2}, []int{1, 2, 0})
+	if a.Risk() <= low {
+		t.Errorf("risk %v does not increase from %v", a.Risk(), low)
+	}
+	for _, c := range a.Contests() {
+		if c.Winner != 0 || c.For != 600 || c.Against != 400 || c.Confirmed {
+			t.Errorf("unexpected contest %+v", c)
+		}
+	}
+}
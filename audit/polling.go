@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"math"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// Polling is a ballot-polling audit: a BRAVO test of every assertion.
+//
+// The test statistic of an assertion starts at 1.
+// A sampled ballot preferring the winner multiplies it by 2s,
+// where s is the reported share of the winner among the voters with a preference in the contest,
+// and a ballot preferring the loser multiplies it by 2(1-s).
+// The assertion is confirmed when the statistic reaches 1/α for the risk limit α.
+type Polling struct {
+	audit
+	stats []float64 // test statistics of the contests
+}
+
+// NewPolling returns a ballot-polling audit of the winner of the result, with the risk limit in (0, 1).
+// The audit escalates to a full recount after limit sampled ballots, 0 for the number of ballots.
+func NewPolling(r condorcet.Result, risk float64, limit int) (*Polling, error) {
+	a, err := newAudit(r, risk, limit)
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]float64, len(a.contests))
+	for k := range stats {
+		stats[k] = 1
+	}
+	return &Polling{a, stats}, nil
+}
+
+// share returns the reported share of the winner of the contest
+// among the voters with a preference.
+func share(c Contest) float64 { return float64(c.For) / float64(c.For+c.Against) }
+
+// SampleSize returns the expected number of ballots to sample to confirm the outcome,
+// if the reported tally is correct.
+// It is the largest expected size of the assertions:
+// ln(1/α) divided by the expected increase of the logarithm of the statistic per ballot.
+func (a *Polling) SampleSize() int {
+	var size int
+	for _, c := range a.contests {
+		s := share(c)
+		drift := s * math.Log(2*s)
+		if s < 1 {
+			drift += (1 - s) * math.Log(2*(1-s))
+		}
+		drift *= float64(c.For+c.Against) / float64(a.ballots)
+		if n := int(math.Ceil(math.Log(1/a.risk) / drift)); n > size {
+			size = n
+		}
+	}
+	return size
+}
+
+// Sample records a sampled ballot, read from paper: the candidates from the prefered one.
+// Candidates missing from a truncated ballot are tied last; an empty ballot expresses no preference.
+// It returns the status of the audit.
+func (a *Polling) Sample(ballot ...int) (Status, error) {
+	pos, err := a.preferences(ballot)
+	if err != nil {
+		return a.Status(), err
+	}
+	a.sampled++
+
+	for k, c := range a.contests {
+		if c.Confirmed {
+			continue
+		}
+		switch vote(pos, c) {
+		case 1:
+			a.stats[k] *= 2 * share(c)
+		case -1:
+			a.stats[k] *= 2 * (1 - share(c))
+		}
+		a.contests[k].Risk = math.Min(1, 1/a.stats[k])
+		a.contests[k].Confirmed = a.stats[k] >= 1/a.risk
+	}
+	return a.Status(), nil
+}
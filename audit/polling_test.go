@@ -0,0 +1,68 @@
+package audit_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/audit"
+)
+
+// election returns the result of an election where 0 beats 1 and 2 by 600 votes to 400.
+func election(t *testing.T) condorcet.Result {
+	t.Helper()
+	e, _ := condorcet.New(3)
+	e.VoteN(600, 0, 1, 2)
+	e.VoteN(400, 1, 2, 0)
+	return e.Result()
+}
+
+// TestPolling checks that a correct outcome is confirmed and that a wrong one escalates.
+func TestPolling(t *testing.T) {
+	r := election(t)
+	if _, err := audit.NewPolling(r, 0, 0); err == nil {
+		t.Error("zero risk limit accepted")
+	}
+	cycle, _ := condorcet.New(3)
+	cycle.Vote(0, 1, 2)
+	cycle.Vote(1, 2, 0)
+	cycle.Vote(2, 0, 1)
+	if _, err := audit.NewPolling(cycle.Result(), 0.05, 0); err == nil {
+		t.Error("audit without winner accepted")
+	}
+
+	// the winner has 60% of the votes in both contests:
+	// each ballot increases the logarithm of the statistic by 0.0201 on average, ln(20) = 2.996
+	a, err := audit.NewPolling(r, 0.05, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := a.SampleSize(); size != 149 {
+		t.Errorf("sample size is %d instead of 149", size)
+	}
+	if _, err := a.Sample(0, 3); err != condorcet.ErrCandidateOutOfRange {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	sample := func(a *audit.Polling, support int) audit.Status {
+		for {
+			ballot := []int{1, 2, 0}
+			if rnd.Intn(1000) < support {
+				ballot = []int{0, 1, 2}
+			}
+			if status, _ := a.Sample(ballot...); status != audit.Continue {
+				return status
+			}
+		}
+	}
+	if status := sample(a, 600); status != audit.Confirmed || a.Risk() >= 0.05 {
+		t.Errorf("audit ends with %v at risk %v", status, a.Risk())
+	}
+
+	// the reported winner actually lost
+	a, _ = audit.NewPolling(r, 0.05, 200)
+	if status := sample(a, 400); status != audit.FullRecount || a.NumSampled() != 200 {
+		t.Errorf("audit ends with %v after %d ballots", status, a.NumSampled())
+	}
+}
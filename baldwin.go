@@ -0,0 +1,45 @@
+package condorcet
+
+// Baldwin returns the outcome of Baldwin's method:
+// the candidates with the lowest Borda score among the running candidates
+// are eliminated, and scores are computed again, until one candidate remains.
+// The Borda score of a candidate is the number of voters preferring it
+// to each other running candidate, summed over these candidates.
+//
+// It returns the winner, false if the last candidates tie,
+// and the rounds of the elimination.
+// Candidates excluded by the eligibility hook do not run.
+// If "none of the above" is among the last candidates, there is no valid winner (see NoneOfTheAbove).
+func (r Result) Baldwin() (w int, unique bool, rounds []Round) {
+	var winners []int
+	r.e.do(PhaseBaldwin, func() { winners, rounds = r.eliminate(r.e.candidates(), r.borda) })
+	w, unique = r.elect(winners)
+	return w, unique, rounds
+}
+
+// borda returns the Borda scores of the running candidates, computed from the sum matrix.
+func (r Result) borda(running []bool) []int {
+	scores := make([]int, r.e.num())
+	for i := range scores {
+		if !running[i] {
+			continue
+		}
+		for j := range scores {
+			if i != j && running[j] {
+				scores[i] += r.e.m.at(i, j)
+			}
+		}
+	}
+	return scores
+}
+
+func init() {
+	completions = append(completions, completion{
+		name: "Baldwin",
+		winners: func(r Result) []int {
+			var winners []int
+			winners, _ = r.eliminate(r.e.candidates(), r.borda)
+			return winners
+		},
+	})
+}
@@ -0,0 +1,39 @@
+package condorcet_test
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestResult_Baldwin checks Baldwin's method on the testcases.
+func TestResult_Baldwin(t *testing.T) {
+	testcases := []struct {
+		label      string
+		winner     int
+		unique     bool
+		eliminated [][]int
+	}{
+		{"Condorcet's example", 2, true, [][]int{{0}, {1}}},
+		{"4 candidates", 3, true, [][]int{{1}, {2}, {0}}},
+		{"paradoxe", 0, true, [][]int{{2}, {1}}},
+		{"Schulze's example", 4, true, [][]int{{3}, {1}, {2}, {0}}},
+	}
+
+	for _, tc := range testcases {
+		w, unique, rounds := result(t, tc.label).Baldwin()
+		if w != tc.winner || unique != tc.unique {
+			t.Errorf("%s: winner (%d, %v) instead of (%d, %v)", tc.label, w, unique, tc.winner, tc.unique)
+		}
+		var eliminated [][]int
+		for _, round := range rounds {
+			eliminated = append(eliminated, round.Eliminated)
+		}
+		if !reflect.DeepEqual(eliminated, tc.eliminated) {
+			t.Errorf("%s: eliminated %v instead of %v", tc.label, eliminated, tc.eliminated)
+		}
+	}
+
+	if _, unique, _ := result(t, "no vote").Baldwin(); unique {
+		t.Error("unique winner without vote")
+	}
+}
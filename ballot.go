@@ -0,0 +1,203 @@
+package condorcet
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxEncodedCandidates is the largest number of candidates
+// for which a ballot can be encoded into a uint64.
+const MaxEncodedCandidates = 20
+
+// Errors returned for invalid ballots (see VoteE).
+var (
+	ErrWrongLength         = errors.New("ballot does not rank the expected number of candidates")
+	ErrDuplicateCandidate  = errors.New("ballot ranks a candidate twice")
+	ErrCandidateOutOfRange = errors.New("ballot ranks an unknown candidate")
+)
+
+// Ballot is a preference order over candidates:
+// first item is the prefered candidate, second is the second choice, and so on.
+// A Ballot can be passed to Vote and its variants with ballot... .
+type Ballot []int
+
+// ParseBallot parses a ballot of the form "2 > 0 > 1", as returned by Ballot.String.
+// It does not validate the ballot (see Validate).
+func ParseBallot(s string) (Ballot, error) {
+	fields := strings.Split(s, ">")
+	b := make(Ballot, len(fields))
+	for i, f := range fields {
+		c, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil {
+			return nil, fmt.Errorf("invalid candidate %q", strings.TrimSpace(f))
+		}
+		b[i] = c
+	}
+	return b, nil
+}
+
+// BallotFromRanks returns the ballot where each candidate of the map
+// is placed according to its rank: the lowest rank is prefered.
+// Ranks need not be consecutive but must be distinct.
+// It does not validate the ballot (see Validate).
+func BallotFromRanks(ranks map[int]int) (Ballot, error) {
+	b := make(Ballot, 0, len(ranks))
+	for c := range ranks {
+		b = append(b, c)
+	}
+	sort.Slice(b, func(i, j int) bool { return ranks[b[i]] < ranks[b[j]] })
+	for i := 1; i < len(b); i++ {
+		if ranks[b[i-1]] == ranks[b[i]] {
+			return nil, fmt.Errorf("candidates %d and %d have the same rank", b[i-1], b[i])
+		}
+	}
+	return b, nil
+}
+
+// Validate checks that the ballot is a total order over numCandidates candidates.
+// It returns ErrWrongLength, ErrDuplicateCandidate or ErrCandidateOutOfRange otherwise.
+func (b Ballot) Validate(numCandidates int) error { return checkOrder(b, numCandidates, false) }
+
+// String returns the ballot in the form "2 > 0 > 1".
+func (b Ballot) String() string {
+	s := make([]string, len(b))
+	for i, c := range b {
+		s[i] = strconv.Itoa(c)
+	}
+	return strings.Join(s, " > ")
+}
+
+// checkOrder checks that the ballot is a total order over n candidates,
+// or over some of them if truncated is true.
+func checkOrder(ballot []int, n int, truncated bool) error {
+	return checkOrderSet(ballot, n, truncated, newBitset(n))
+}
+
+// checkOrderSet implements checkOrder with seen, an empty set of at least n candidates,
+// which is left empty.
+func checkOrderSet(ballot []int, n int, truncated bool, seen bitset) (err error) {
+	if len(ballot) > n || len(ballot) == 0 || (!truncated && len(ballot) != n) {
+		return ErrWrongLength
+	}
+	k := 0
+	for ; k < len(ballot); k++ {
+		candidate := ballot[k]
+		if candidate < 0 || candidate >= n {
+			err = ErrCandidateOutOfRange
+			break
+		}
+		if seen.has(candidate) {
+			err = ErrDuplicateCandidate
+			break
+		}
+		seen.add(candidate)
+	}
+	for _, candidate := range ballot[:k] {
+		seen.remove(candidate)
+	}
+	return err
+}
+
+// bitset is a set of candidates.
+type bitset []uint64
+
+// newBitset returns an empty set of n candidates.
+func newBitset(n int) bitset { return make(bitset, (n+63)/64) }
+
+func (s bitset) has(c int) bool { return s[c/64]&(1<<uint(c%64)) != 0 }
+func (s bitset) add(c int)      { s[c/64] |= 1 << uint(c%64) }
+func (s bitset) remove(c int)   { s[c/64] &^= 1 << uint(c%64) }
+
+// isTotalOrder checks that the ballot is a total order over n candidates,
+// i.e. a permutation of 0, 1, ..., n-1.
+func isTotalOrder(ballot []int, n int) bool {
+	if len(ballot) != n {
+		return false
+	}
+	candidates := make([]int, n)
+	for _, candidate := range ballot {
+		if candidate < 0 || candidate >= n {
+			return false
+		}
+		candidates[candidate]++
+	}
+	for _, count := range candidates {
+		if count != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// factorial returns n!.
+// It does not check for overflow.
+func factorial(n int) uint64 {
+	f := uint64(1)
+	for i := 2; i <= n; i++ {
+		f *= uint64(i)
+	}
+	return f
+}
+
+// EncodeBallot returns the Lehmer code of the ballot,
+// a unique integer in [0, n!) where n is the length of the ballot.
+//
+// The ballot must be a total order over at most MaxEncodedCandidates candidates.
+func EncodeBallot(ballot []int) (uint64, error) {
+	n := len(ballot)
+	if n > MaxEncodedCandidates {
+		return 0, errors.New("too many candidates to encode the ballot")
+	}
+	if !isTotalOrder(ballot, n) {
+		return 0, errors.New("ballot is not a total order")
+	}
+	return encode(ballot), nil
+}
+
+// encode returns the Lehmer code of the valid ballot (see EncodeBallot).
+func encode(ballot []int) uint64 {
+	n := len(ballot)
+	var code uint64
+	for i, candidate := range ballot {
+		// number of candidates ranked after this one with a smaller index
+		var smaller uint64
+		for _, other := range ballot[i+1:] {
+			if other < candidate {
+				smaller++
+			}
+		}
+		code += smaller * factorial(n-1-i)
+	}
+	return code
+}
+
+// DecodeBallot returns the ballot over n candidates whose Lehmer code is code.
+// It is the inverse of EncodeBallot.
+func DecodeBallot(code uint64, n int) ([]int, error) {
+	if n < 0 || n > MaxEncodedCandidates {
+		return nil, errors.New("invalid number of candidates")
+	}
+	if n > 0 && code >= factorial(n) {
+		return nil, errors.New("code is out of range")
+	}
+
+	// candidates not placed in the ballot yet, in increasing order
+	remaining := make([]int, n)
+	for i := range remaining {
+		remaining[i] = i
+	}
+
+	ballot := make([]int, n)
+	for i := range ballot {
+		f := factorial(n - 1 - i)
+		k := int(code / f)
+		code %= f
+
+		ballot[i] = remaining[k]
+		remaining = append(remaining[:k], remaining[k+1:]...)
+	}
+	return ballot, nil
+}
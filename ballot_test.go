@@ -0,0 +1,87 @@
+package condorcet_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestEncodeBallot asserts that all permutations of 4 candidates
+// get distinct codes in [0, 4!) and that decoding is the inverse of encoding.
+func TestEncodeBallot(t *testing.T) {
+	seen := make(map[uint64]bool)
+	for code := uint64(0); code < 24; code++ {
+		ballot, err := condorcet.DecodeBallot(code, 4)
+		if err != nil {
+			t.Fatalf("cannot decode %d: %v", code, err)
+		}
+
+		c, err := condorcet.EncodeBallot(ballot)
+		if err != nil {
+			t.Fatalf("cannot encode %v: %v", ballot, err)
+		}
+		if c != code {
+			t.Errorf("%v is encoded as %d instead of %d", ballot, c, code)
+		}
+		if seen[c] {
+			t.Errorf("code %d is used twice", c)
+		}
+		seen[c] = true
+	}
+
+	// identity is 0, reverse order is n!-1
+	if c, _ := condorcet.EncodeBallot([]int{0, 1, 2, 3}); c != 0 {
+		t.Errorf("identity is encoded as %d", c)
+	}
+	if b, _ := condorcet.DecodeBallot(23, 4); !reflect.DeepEqual(b, []int{3, 2, 1, 0}) {
+		t.Errorf("23 is decoded as %v", b)
+	}
+}
+
+// TestEncodeBallot_invalid asserts that invalid ballots and codes are rejected.
+func TestEncodeBallot_invalid(t *testing.T) {
+	if _, err := condorcet.EncodeBallot([]int{0, 0, 1}); err == nil {
+		t.Error("encoding a ballot with a duplicate did not fail")
+	}
+	if _, err := condorcet.EncodeBallot(make([]int, condorcet.MaxEncodedCandidates+1)); err == nil {
+		t.Error("encoding a too long ballot did not fail")
+	}
+	if _, err := condorcet.DecodeBallot(6, 3); err == nil {
+		t.Error("decoding an out of range code did not fail")
+	}
+}
+
+// TestBallot checks the construction and validation of ballots.
+func TestBallot(t *testing.T) {
+	b, err := condorcet.ParseBallot("2 > 0>1")
+	if err != nil || !reflect.DeepEqual(b, condorcet.Ballot{2, 0, 1}) {
+		t.Fatalf("parsed (%v, %v) instead of [2 0 1]", b, err)
+	}
+	if s := b.String(); s != "2 > 0 > 1" {
+		t.Errorf("ballot printed as %q", s)
+	}
+	if _, err := condorcet.ParseBallot("2 > x"); err == nil {
+		t.Error("invalid ballot parsed")
+	}
+
+	b, err = condorcet.BallotFromRanks(map[int]int{0: 2, 1: 3, 2: 1})
+	if err != nil || !reflect.DeepEqual(b, condorcet.Ballot{2, 0, 1}) {
+		t.Errorf("ranks give (%v, %v) instead of [2 0 1]", b, err)
+	}
+	if _, err := condorcet.BallotFromRanks(map[int]int{0: 1, 1: 1}); err == nil {
+		t.Error("ranks with a tie accepted")
+	}
+
+	if err := b.Validate(3); err != nil {
+		t.Errorf("valid ballot rejected: %v", err)
+	}
+	if err := b.Validate(4); err != condorcet.ErrWrongLength {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	e, _ := condorcet.New(3)
+	if !e.Vote(b...) {
+		t.Error("ballot rejected by the election")
+	}
+}
@@ -0,0 +1,247 @@
+package ballotio
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// ABIF is the description of an election in an ABIF file, the Aggregated Ballot Information Format:
+//
+//	{"title": "Board election"}
+//	=A:[Alice]
+//	=B:[Bob]
+//	27:A>B>[Carol Ann]
+//	12:B=[Carol Ann]>A
+//
+// Lines starting with "=" declare the candidates, with a token and an optional name,
+// and the other lines are ballots preceded by their number.
+// Candidates are separated by ">", or by "=" for a tie.
+// Ratings such as "A/5" are ignored, as are comments, starting with "#".
+type ABIF struct {
+	Title string
+}
+
+// abifBallot is a ballot line of an ABIF file.
+type abifBallot struct {
+	line   int
+	count  uint
+	groups [][]string // tokens
+}
+
+// ReadABIF returns the election described by an ABIF file.
+// The candidates are the declared ones, then the undeclared ones in order of appearance;
+// they are named after their declared name or their token.
+// The options are those of the election (see condorcet.NewNamed).
+// Ballots ranking some of the candidates are only accepted if truncation is allowed (see condorcet.AllowTruncation).
+func ReadABIF(r io.Reader, opts ...condorcet.Option) (*condorcet.Election, ABIF, error) {
+	var (
+		abif    ABIF
+		names   []string
+		index   = make(map[string]int) // candidate of each token
+		ballots []abifBallot
+	)
+	candidate := func(token string) int {
+		c, ok := index[token]
+		if !ok {
+			c = len(names)
+			index[token] = c
+			names = append(names, strings.Trim(token, "[]"))
+		}
+		return c
+	}
+
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		text := s.Text()
+		if k := strings.IndexByte(text, '#'); k >= 0 {
+			text = text[:k]
+		}
+		text = strings.TrimSpace(text)
+
+		switch {
+		case text == "":
+		case text[0] == '{':
+			var meta map[string]interface{}
+			if err := json.Unmarshal([]byte(text), &meta); err != nil {
+				return nil, abif, fmt.Errorf("line %d: invalid metadata: %v", line, err)
+			}
+			if title, ok := meta["title"].(string); ok {
+				abif.Title = title
+			}
+		case text[0] == '=':
+			decl := strings.SplitN(text[1:], ":", 2)
+			token := strings.TrimSpace(decl[0])
+			if _, ok := index[token]; ok || !validToken(token) {
+				return nil, abif, fmt.Errorf("line %d: invalid declaration of %q", line, token)
+			}
+			c := candidate(token)
+			if len(decl) == 2 {
+				names[c] = strings.Trim(strings.TrimSpace(decl[1]), "[]")
+			}
+		default:
+			b, err := parseABIFBallot(text)
+			if err != nil {
+				return nil, abif, fmt.Errorf("line %d: %v", line, err)
+			}
+			for _, g := range b.groups {
+				for _, token := range g {
+					candidate(token)
+				}
+			}
+			b.line = line
+			ballots = append(ballots, b)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, abif, err
+	}
+
+	e, err := condorcet.NewNamed(names, opts...)
+	if err != nil {
+		return nil, abif, err
+	}
+	for _, b := range ballots {
+		if b.count == 0 || len(b.groups) == 0 {
+			continue
+		}
+		groups := make([][]int, len(b.groups))
+		for k, g := range b.groups {
+			for _, token := range g {
+				groups[k] = append(groups[k], index[token])
+			}
+		}
+		if err := vote(e, groups, b.count); err != nil {
+			return nil, abif, fmt.Errorf("line %d: %v", b.line, err)
+		}
+	}
+	return e, abif, nil
+}
+
+// parseABIFBallot parses a ballot line: its number and its groups of tokens.
+func parseABIFBallot(text string) (abifBallot, error) {
+	var b abifBallot
+	kv := strings.SplitN(text, ":", 2)
+	if len(kv) != 2 {
+		return b, fmt.Errorf("invalid ballot %q", text)
+	}
+	count, err := strconv.ParseUint(strings.TrimSpace(kv[0]), 10, 0)
+	if err != nil {
+		return b, fmt.Errorf("invalid number of ballots %q", kv[0])
+	}
+	b.count = uint(count)
+
+	prefs := strings.TrimSpace(kv[1])
+	if prefs == "" {
+		return b, nil
+	}
+	group := []string{}
+	for len(prefs) > 0 {
+		var token string
+		switch {
+		case prefs[0] == '[':
+			end := strings.IndexByte(prefs, ']')
+			if end < 0 {
+				return b, errors.New("unterminated candidate name")
+			}
+			token, prefs = prefs[:end+1], prefs[end+1:]
+		default:
+			end := strings.IndexFunc(prefs, func(r rune) bool { return !isTokenRune(r) })
+			if end < 0 {
+				end = len(prefs)
+			}
+			if end == 0 {
+				return b, fmt.Errorf("unexpected %q", prefs[0])
+			}
+			token, prefs = prefs[:end], prefs[end:]
+		}
+		group = append(group, token)
+
+		prefs = strings.TrimSpace(prefs)
+		if strings.HasPrefix(prefs, "/") { // rating
+			end := strings.IndexAny(prefs, ">=,")
+			if end < 0 {
+				end = len(prefs)
+			}
+			prefs = strings.TrimSpace(prefs[end:])
+		}
+		if prefs == "" {
+			break
+		}
+		switch prefs[0] {
+		case '>':
+			b.groups = append(b.groups, group)
+			group = []string{}
+		case '=', ',':
+		default:
+			return b, fmt.Errorf("unexpected %q", prefs[0])
+		}
+		prefs = strings.TrimSpace(prefs[1:])
+		if prefs == "" {
+			return b, errors.New("missing candidate")
+		}
+	}
+	b.groups = append(b.groups, group)
+	return b, nil
+}
+
+// isTokenRune reports whether r may be part of a bare candidate token.
+func isTokenRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-'
+}
+
+// validToken reports whether the candidate token is bare or bracketed.
+func validToken(token string) bool {
+	if strings.HasPrefix(token, "[") {
+		return len(token) > 2 && strings.IndexByte(token, ']') == len(token)-1
+	}
+	return token != "" && strings.IndexFunc(token, func(r rune) bool { return !isTokenRune(r) }) < 0
+}
+
+// WriteABIF writes the ballots of the result as an ABIF file.
+// Candidates are declared with their name and identical ballots are written once, with their number.
+//
+// It requires the full profile of the election (see condorcet.Exact).
+func WriteABIF(w io.Writer, r condorcet.Result, abif ABIF) error {
+	profile := r.Profile()
+	if profile == nil {
+		return condorcet.ErrNoProfile
+	}
+	n := r.NumCandidates()
+
+	bw := bufio.NewWriter(w)
+	if abif.Title != "" {
+		meta, _ := json.Marshal(map[string]string{"title": abif.Title})
+		bw.Write(meta)
+		bw.WriteByte('\n')
+	}
+	for c := 0; c < n; c++ {
+		name := r.Name(c)
+		if strings.ContainsAny(name, "[]#\n") {
+			return fmt.Errorf("name %q of candidate %d cannot be written", name, c)
+		}
+		fmt.Fprintf(bw, "=c%d:[%s]\n", c, name)
+	}
+	for code, count := range profile {
+		if count == 0 {
+			continue
+		}
+		ballot, _ := condorcet.DecodeBallot(uint64(code), n)
+		fmt.Fprintf(bw, "%d:", count)
+		for k, c := range ballot {
+			if k > 0 {
+				bw.WriteByte('>')
+			}
+			fmt.Fprintf(bw, "c%d", c)
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
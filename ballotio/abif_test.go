@@ -0,0 +1,68 @@
+package ballotio_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/ballotio"
+)
+
+// TestReadABIF reads an ABIF file with declarations, ties, ratings and comments.
+func TestReadABIF(t *testing.T) {
+	data := `# Condorcet's example
+{"title": "Board election"}
+=A:[Alice]
+=B
+23:A>B>[Carol Ann]
+19:B>[Carol Ann]>A
+16:[Carol Ann]/5>B/3>A/0 # rated
+2:[Carol Ann]>A>B
+4:A=B>[Carol Ann]
+`
+	e, abif, err := ballotio.ReadABIF(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if abif.Title != "Board election" || e.NumBallots() != 64 {
+		t.Errorf("title %q and %d ballots", abif.Title, e.NumBallots())
+	}
+	r := e.Result()
+	if r.Name(0) != "Alice" || r.Name(1) != "B" || r.Name(2) != "Carol Ann" {
+		t.Errorf("candidates are %q, %q and %q", r.Name(0), r.Name(1), r.Name(2))
+	}
+	if w, ok := r.WinnerName(); !ok || w != "B" {
+		t.Errorf("winner is (%s, %v) instead of B", w, ok)
+	}
+
+	for _, invalid := range []string{"3:A>", "3:A>>B", "x:A>B", "=A\n=A", "3:[A>B"} {
+		if _, _, err := ballotio.ReadABIF(strings.NewReader(invalid)); err == nil {
+			t.Errorf("%q accepted", invalid)
+		}
+	}
+}
+
+// TestWriteABIF writes an election and reads it back.
+func TestWriteABIF(t *testing.T) {
+	e, _ := condorcet.NewNamed([]string{"Alice", "Bob", "Carol Ann"}, condorcet.Exact())
+	e.VoteN(3, 2, 0, 1)
+	e.Vote(0, 1, 2)
+
+	var buf bytes.Buffer
+	if err := ballotio.WriteABIF(&buf, e.Result(), ballotio.ABIF{Title: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	want := "{\"title\":\"test\"}\n=c0:[Alice]\n=c1:[Bob]\n=c2:[Carol Ann]\n1:c0>c1>c2\n3:c2>c0>c1\n"
+	if buf.String() != want {
+		t.Errorf("written file is\n%s\ninstead of\n%s", buf.String(), want)
+	}
+
+	read, abif, err := ballotio.ReadABIF(&buf, condorcet.Exact())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if abif.Title != "test" || !read.Result().Equal(e.Result()) {
+		t.Error("election read back differs from the written one")
+	}
+}
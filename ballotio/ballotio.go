@@ -0,0 +1,26 @@
+// Package ballotio reads and writes ballots in common file formats:
+// CSV, BLT, PrefLib and ABIF.
+package ballotio
+
+import (
+	"errors"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// vote votes count times the ballot with ties in the election.
+// Each group is a set of equally prefered candidates (see condorcet.Election.VoteRanked).
+func vote(e *condorcet.Election, groups [][]int, count uint) error {
+	var ballot []int
+	for _, g := range groups {
+		if len(g) != 1 {
+			if !e.VoteRankedN(count, groups...) {
+				return errors.New("ballot with ties rejected")
+			}
+			return nil
+		}
+		ballot = append(ballot, g[0])
+	}
+
+	return e.VoteNE(count, ballot...)
+}
@@ -0,0 +1,195 @@
+package ballotio
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// BLT is the description of an election in a BLT file, the format of OpenSTV and many ranked-ballot tools:
+//
+//	3 1           number of candidates and of seats
+//	-2            withdrawn candidates, optional
+//	4 1 3 2 0     weight of the ballot and candidates from 1, ended by 0; "2=3" is a tie
+//	0             end of the ballots
+//	"Alice"       names of the candidates
+//	"Bob"
+//	"Carol"
+//	"Title"
+type BLT struct {
+	Title     string
+	Seats     int
+	Withdrawn []int // see condorcet.Result.Withdraw
+}
+
+// ReadBLT returns the election described by a BLT file.
+// The candidates are named after the file and the options are those of the election (see condorcet.NewNamed).
+// Ballots ranking some of the candidates are only accepted if truncation is allowed (see condorcet.AllowTruncation).
+func ReadBLT(r io.Reader, opts ...condorcet.Option) (*condorcet.Election, BLT, error) {
+	var (
+		blt     BLT
+		ballots [][][]int
+		weights []uint
+		lines   []int
+	)
+	s := bufio.NewScanner(r)
+	line := 0
+	next := func() ([]string, bool) {
+		for s.Scan() {
+			line++
+			if fields := strings.Fields(s.Text()); len(fields) > 0 && !strings.HasPrefix(fields[0], "#") {
+				return fields, true
+			}
+		}
+		return nil, false
+	}
+	fail := func(format string, a ...interface{}) (*condorcet.Election, BLT, error) {
+		if err := s.Err(); err != nil {
+			return nil, blt, err
+		}
+		return nil, blt, fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, a...))
+	}
+
+	fields, ok := next()
+	if !ok || len(fields) != 2 {
+		return fail("expecting the number of candidates and of seats")
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n < 2 {
+		return fail("invalid number of candidates %q", fields[0])
+	}
+	if blt.Seats, err = strconv.Atoi(fields[1]); err != nil || blt.Seats < 1 {
+		return fail("invalid number of seats %q", fields[1])
+	}
+
+	// withdrawn candidates and ballots
+	for {
+		if fields, ok = next(); !ok {
+			return fail("missing end of ballots")
+		}
+		if fields[0] == "0" {
+			break
+		}
+		if strings.HasPrefix(fields[0], "-") {
+			for _, f := range fields {
+				c, err := strconv.Atoi(f)
+				if err != nil || c >= 0 || -c > n {
+					return fail("invalid withdrawn candidate %q", f)
+				}
+				blt.Withdrawn = append(blt.Withdrawn, -c-1)
+			}
+			continue
+		}
+		if strings.HasPrefix(fields[0], "(") { // ballot identifier
+			fields = fields[1:]
+		}
+
+		if len(fields) < 2 || fields[len(fields)-1] != "0" {
+			return fail("ballot not ended by 0")
+		}
+		weight, err := strconv.ParseUint(fields[0], 10, 0)
+		if err != nil {
+			return fail("invalid weight %q", fields[0])
+		}
+		var groups [][]int
+		for _, f := range fields[1 : len(fields)-1] {
+			var g []int
+			for _, c := range strings.Split(f, "=") {
+				k, err := strconv.Atoi(c)
+				if err != nil || k < 1 || k > n {
+					return fail("invalid candidate %q", c)
+				}
+				g = append(g, k-1)
+			}
+			groups = append(groups, g)
+		}
+		ballots = append(ballots, groups)
+		weights = append(weights, uint(weight))
+		lines = append(lines, line)
+	}
+
+	// names and title
+	names := make([]string, n)
+	for c := range names {
+		if _, ok := next(); !ok {
+			return fail("missing name of candidate %d", c+1)
+		}
+		if names[c], err = strconv.Unquote(strings.TrimSpace(s.Text())); err != nil {
+			return fail("invalid name %q", s.Text())
+		}
+	}
+	if _, ok := next(); ok {
+		if blt.Title, err = strconv.Unquote(strings.TrimSpace(s.Text())); err != nil {
+			return fail("invalid title %q", s.Text())
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, blt, err
+	}
+
+	e, err := condorcet.NewNamed(names, opts...)
+	if err != nil {
+		return nil, blt, err
+	}
+	for k, groups := range ballots {
+		if weights[k] == 0 {
+			continue
+		}
+		if err := vote(e, groups, weights[k]); err != nil {
+			return nil, blt, fmt.Errorf("line %d: %v", lines[k], err)
+		}
+	}
+	return e, blt, nil
+}
+
+// WriteBLT writes the ballots of the result as a BLT file.
+// Identical ballots are written once, with their number as weight.
+//
+// It requires the full profile of the election (see condorcet.Exact).
+func WriteBLT(w io.Writer, r condorcet.Result, blt BLT) error {
+	profile := r.Profile()
+	if profile == nil {
+		return condorcet.ErrNoProfile
+	}
+	n := r.NumCandidates()
+	if blt.Seats == 0 {
+		blt.Seats = 1
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%d %d\n", n, blt.Seats)
+	if len(blt.Withdrawn) > 0 {
+		for k, c := range blt.Withdrawn {
+			if c < 0 || c >= n {
+				return errors.New("withdrawn candidate out of range")
+			}
+			if k > 0 {
+				bw.WriteByte(' ')
+			}
+			fmt.Fprintf(bw, "-%d", c+1)
+		}
+		bw.WriteByte('\n')
+	}
+	for code, count := range profile {
+		if count == 0 {
+			continue
+		}
+		ballot, _ := condorcet.DecodeBallot(uint64(code), n)
+		fmt.Fprintf(bw, "%d", count)
+		for _, c := range ballot {
+			fmt.Fprintf(bw, " %d", c+1)
+		}
+		bw.WriteString(" 0\n")
+	}
+	bw.WriteString("0\n")
+	for c := 0; c < n; c++ {
+		bw.WriteString(strconv.Quote(r.Name(c)) + "\n")
+	}
+	bw.WriteString(strconv.Quote(blt.Title) + "\n")
+	return bw.Flush()
+}
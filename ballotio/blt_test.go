@@ -0,0 +1,108 @@
+package ballotio_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/ballotio"
+)
+
+// TestReadBLT reads a BLT file with truncated ballots, ties and a withdrawn candidate.
+func TestReadBLT(t *testing.T) {
+	data := `3 1
+-2
+4 3 1 2 0
+2 1 3 0
+1 2=3 1 0
+0
+"Alice"
+"Bob"
+"Carol Ann"
+"Board election"
+`
+	e, blt, err := ballotio.ReadBLT(strings.NewReader(data), condorcet.AllowTruncation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blt.Title != "Board election" || blt.Seats != 1 || !reflect.DeepEqual(blt.Withdrawn, []int{1}) {
+		t.Errorf("unexpected description %+v", blt)
+	}
+	if e.NumBallots() != 7 || e.Name(2) != "Carol Ann" {
+		t.Errorf("%d ballots, candidate 2 is %q", e.NumBallots(), e.Name(2))
+	}
+	if w, ok := e.Result().WinnerName(); !ok || w != "Carol Ann" {
+		t.Errorf("winner is (%s, %v)", w, ok)
+	}
+
+	// truncated ballots require the option
+	if _, _, err := ballotio.ReadBLT(strings.NewReader(data)); err == nil || !strings.HasPrefix(err.Error(), "line 4:") {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, _, err := ballotio.ReadBLT(strings.NewReader("3 1\n1 4 0\n0\n")); err == nil {
+		t.Error("unknown candidate accepted")
+	}
+}
+
+// TestWriteBLT writes an election and reads it back.
+func TestWriteBLT(t *testing.T) {
+	e, _ := condorcet.NewNamed([]string{"Alice", "Bob", "Carol"}, condorcet.Exact())
+	e.VoteN(3, 2, 0, 1)
+	e.Vote(0, 1, 2)
+
+	var buf bytes.Buffer
+	if err := ballotio.WriteBLT(&buf, e.Result(), ballotio.BLT{Title: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	want := "3 1\n1 1 2 3 0\n3 3 1 2 0\n0\n\"Alice\"\n\"Bob\"\n\"Carol\"\n\"test\"\n"
+	if buf.String() != want {
+		t.Errorf("written file is\n%s\ninstead of\n%s", buf.String(), want)
+	}
+
+	read, blt, err := ballotio.ReadBLT(&buf, condorcet.Exact())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blt.Title != "test" || !read.Result().Equal(e.Result()) {
+		t.Error("election read back differs from the written one")
+	}
+
+	e, _ = condorcet.New(3)
+	if err := ballotio.WriteBLT(&buf, e.Result(), ballotio.BLT{}); err != condorcet.ErrNoProfile {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+// TestReadBLT_weightedTies reads a heavily weighted ballot with ties in one step.
+func TestReadBLT_weightedTies(t *testing.T) {
+	data := "3 1\n1000000000000 1=2 3 0\n0\n\"A\"\n\"B\"\n\"C\"\n\"T\"\n"
+	e, _, err := ballotio.ReadBLT(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.NumVoters() != 1000000000000 {
+		t.Errorf("%d voters", e.NumVoters())
+	}
+}
+
+// TestReadBLT_weighted checks that a weighted row is counted at once, or not at all.
+func TestReadBLT_weighted(t *testing.T) {
+	var journal bytes.Buffer
+	data := "3 1\n3 1 2 3 0\n0\n\"A\"\n\"B\"\n\"C\"\n\"T\"\n"
+	e, _, err := ballotio.ReadBLT(strings.NewReader(data), condorcet.Journal(&journal))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.NumVoters() != 3 || journal.String() != "3 0 1 2\n" {
+		t.Errorf("%d voters and journal %q", e.NumVoters(), journal.String())
+	}
+
+	// the row overflows 32-bit counters: nothing is counted
+	data = "3 1\n4294967296 1 2 3 0\n0\n\"A\"\n\"B\"\n\"C\"\n\"T\"\n"
+	e, _, err = ballotio.ReadBLT(strings.NewReader(data), condorcet.TallyWidth(32))
+	if err == nil || e != nil && e.NumVoters() != 0 {
+		t.Errorf("overflowing row counted: %v", err)
+	}
+}
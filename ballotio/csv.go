@@ -0,0 +1,184 @@
+package ballotio
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// Layout tells how the columns of a CSV row describe a ballot.
+type Layout int
+
+// Layouts of CSV ballots.
+const (
+	// RankColumns: each column is a candidate and holds its rank, 1 being the prefered one.
+	// Equal ranks are ties (see condorcet.Election.VoteRanked)
+	// and empty cells are unranked candidates (see condorcet.AllowTruncation).
+	RankColumns Layout = iota
+	// ChoiceColumns: column k holds the candidate ranked k-th, by name or by index.
+	// Empty cells are ignored.
+	ChoiceColumns
+)
+
+// CSV reads ballots from CSV files, one ballot per row.
+type CSV struct {
+	Layout Layout
+
+	// Header tells whether the first row is a header.
+	// With RankColumns, the header names the candidate of each column, by name or by index;
+	// otherwise column k is candidate k.
+	Header bool
+
+	// Columns are the indices of the columns holding the ballot, e.g. to skip a voter identifier.
+	// If nil, all columns are used.
+	Columns []int
+
+	// Comma is the field delimiter. If zero, it is ','.
+	Comma rune
+}
+
+// RowError is the error of a rejected row.
+type RowError struct {
+	Row int // starting from 1, header included
+	Err error
+}
+
+func (e RowError) Error() string { return fmt.Sprintf("row %d: %v", e.Row, e.Err) }
+
+// Read votes the ballots read from r in the election.
+// It returns the number of ballots cast and the errors of the rejected rows,
+// which do not stop the reading.
+// An error is returned if the file is not valid CSV.
+func (c CSV) Read(r io.Reader, e *condorcet.Election) (cast int, rejected []RowError, err error) {
+	cr := csv.NewReader(r)
+	if c.Comma != 0 {
+		cr.Comma = c.Comma
+	}
+	cr.FieldsPerRecord = -1
+
+	var candidates []int // candidate of each column, with RankColumns
+	for row := 1; ; row++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return cast, rejected, nil
+		}
+		if err != nil {
+			return cast, rejected, err
+		}
+		cells, err := c.cells(record)
+		if err != nil {
+			rejected = append(rejected, RowError{row, err})
+			continue
+		}
+
+		if row == 1 && c.Header {
+			if c.Layout != RankColumns {
+				continue
+			}
+			for _, name := range cells {
+				candidate, ok := lookup(e, name)
+				if !ok {
+					return cast, rejected, fmt.Errorf("unknown candidate %q in header", name)
+				}
+				candidates = append(candidates, candidate)
+			}
+			continue
+		}
+
+		switch c.Layout {
+		case RankColumns:
+			err = voteRanks(e, cells, candidates)
+		case ChoiceColumns:
+			err = voteChoices(e, cells)
+		default:
+			return cast, rejected, fmt.Errorf("unknown layout %d", c.Layout)
+		}
+		if err != nil {
+			rejected = append(rejected, RowError{row, err})
+			continue
+		}
+		cast++
+	}
+}
+
+// cells returns the cells of the ballot columns.
+func (c CSV) cells(record []string) ([]string, error) {
+	if c.Columns == nil {
+		return record, nil
+	}
+	cells := make([]string, len(c.Columns))
+	for k, col := range c.Columns {
+		if col < 0 || col >= len(record) {
+			return nil, fmt.Errorf("missing column %d", col)
+		}
+		cells[k] = record[col]
+	}
+	return cells, nil
+}
+
+// voteRanks votes the ballot given by the ranks of the candidates.
+// If candidates is nil, cell k is the rank of candidate k.
+func voteRanks(e *condorcet.Election, cells []string, candidates []int) error {
+	if candidates != nil && len(cells) != len(candidates) {
+		return fmt.Errorf("%d cells instead of %d", len(cells), len(candidates))
+	}
+	ranks := make(map[int][]int)
+	for k, cell := range cells {
+		cell = strings.TrimSpace(cell)
+		if cell == "" {
+			continue
+		}
+		rank, err := strconv.Atoi(cell)
+		if err != nil || rank < 1 {
+			return fmt.Errorf("invalid rank %q", cell)
+		}
+		candidate := k
+		if candidates != nil {
+			candidate = candidates[k]
+		}
+		ranks[rank] = append(ranks[rank], candidate)
+	}
+
+	order := make([]int, 0, len(ranks))
+	for rank := range ranks {
+		order = append(order, rank)
+	}
+	sort.Ints(order)
+	groups := make([][]int, len(order))
+	for k, rank := range order {
+		groups[k] = ranks[rank]
+	}
+	return vote(e, groups, 1)
+}
+
+// voteChoices votes the ballot given by the candidates in order of preference.
+func voteChoices(e *condorcet.Election, cells []string) error {
+	var groups [][]int
+	for _, cell := range cells {
+		cell = strings.TrimSpace(cell)
+		if cell == "" {
+			continue
+		}
+		candidate, ok := lookup(e, cell)
+		if !ok {
+			return fmt.Errorf("unknown candidate %q", cell)
+		}
+		groups = append(groups, []int{candidate})
+	}
+	return vote(e, groups, 1)
+}
+
+// lookup returns the candidate given by its name or by its index.
+func lookup(e *condorcet.Election, cell string) (int, bool) {
+	cell = strings.TrimSpace(cell)
+	if candidate, ok := e.Index(cell); ok {
+		return candidate, true
+	}
+	candidate, err := strconv.Atoi(cell)
+	return candidate, err == nil
+}
@@ -0,0 +1,48 @@
+package ballotio_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/ballotio"
+)
+
+// TestCSV_Read reads ballots in both layouts and reports the invalid rows.
+func TestCSV_Read(t *testing.T) {
+	e, _ := condorcet.NewNamed([]string{"alice", "bob", "carol"})
+	data := "id,carol,alice,bob\n" +
+		"v1,1,2,3\n" +
+		"v2,1,1,2\n" + // tie
+		"v3,1,x,2\n" + // invalid rank
+		"v4,1,2\n" // missing column
+	c := ballotio.CSV{Layout: ballotio.RankColumns, Header: true, Columns: []int{1, 2, 3}}
+	cast, rejected, err := c.Read(strings.NewReader(data), e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cast != 2 || len(rejected) != 2 || rejected[0].Row != 4 || rejected[1].Row != 5 {
+		t.Errorf("%d ballots cast and rows %v rejected", cast, rejected)
+	}
+	if w, ok := e.Result().WinnerName(); !ok || w != "carol" {
+		t.Errorf("winner is (%s, %v) instead of carol", w, ok)
+	}
+
+	e, _ = condorcet.NewNamed([]string{"alice", "bob", "carol"})
+	data = "bob;alice;carol\n" +
+		"2;0;1\n" + // by index
+		"bob;bob;carol\n" + // duplicate
+		"bob;dave;carol\n" // unknown candidate
+	c = ballotio.CSV{Layout: ballotio.ChoiceColumns, Comma: ';'}
+	cast, rejected, err = c.Read(strings.NewReader(data), e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cast != 2 || len(rejected) != 2 || rejected[0].Err != condorcet.ErrDuplicateCandidate {
+		t.Errorf("%d ballots cast and rows %v rejected", cast, rejected)
+	}
+
+	if _, _, err := c.Read(strings.NewReader("\"bob\n"), e); err == nil {
+		t.Error("invalid CSV accepted")
+	}
+}
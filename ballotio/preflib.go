@@ -0,0 +1,155 @@
+package ballotio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// PrefLib data types read by ReadPrefLib.
+const (
+	SOC = "soc" // strict orders, complete
+	SOI = "soi" // strict orders, incomplete
+)
+
+// PrefLib is the description of a PrefLib dataset, given by the metadata of its file.
+type PrefLib struct {
+	Title    string
+	DataType string // SOC or SOI
+	Metadata map[string]string
+}
+
+// ReadPrefLib returns the election of a PrefLib SOC or SOI file:
+//
+//	# DATA TYPE: soc
+//	# NUMBER ALTERNATIVES: 3
+//	# NUMBER VOTERS: 8
+//	# ALTERNATIVE NAME 1: Alice
+//	...
+//	5: 3,1,2
+//	3: 1,2,3
+//
+// The candidates are named after the alternatives
+// and the options are those of the election (see condorcet.NewNamed).
+// Truncated ballots are allowed for SOI files (see condorcet.AllowTruncation).
+// The declared numbers of voters and of unique orders are checked.
+func ReadPrefLib(r io.Reader, opts ...condorcet.Option) (*condorcet.Election, PrefLib, error) {
+	pl := PrefLib{Metadata: make(map[string]string)}
+	var (
+		e    *condorcet.Election
+		line int
+	)
+	fail := func(format string, a ...interface{}) (*condorcet.Election, PrefLib, error) {
+		return nil, pl, fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, a...))
+	}
+
+	voters, unique := 0, 0
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line++
+		text := strings.TrimSpace(s.Text())
+		if text == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "#") {
+			if e != nil {
+				return fail("metadata after the orders")
+			}
+			kv := strings.SplitN(strings.TrimPrefix(text, "#"), ":", 2)
+			if len(kv) != 2 {
+				return fail("invalid metadata %q", text)
+			}
+			pl.Metadata[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+			continue
+		}
+
+		if e == nil {
+			var err error
+			if e, err = newPrefLib(&pl, opts); err != nil {
+				return fail("%v", err)
+			}
+		}
+		kv := strings.SplitN(text, ":", 2)
+		if len(kv) != 2 {
+			return fail("invalid order %q", text)
+		}
+		count, err := strconv.ParseUint(strings.TrimSpace(kv[0]), 10, 0)
+		if err != nil {
+			return fail("invalid count %q", kv[0])
+		}
+		var groups [][]int
+		for _, f := range strings.Split(kv[1], ",") {
+			c, err := strconv.Atoi(strings.TrimSpace(f))
+			if err != nil {
+				return fail("invalid alternative %q", f)
+			}
+			groups = append(groups, []int{c - 1})
+		}
+		if count > 0 {
+			if err := vote(e, groups, uint(count)); err != nil {
+				return fail("%v", err)
+			}
+		}
+		voters += int(count)
+		unique++
+	}
+	if err := s.Err(); err != nil {
+		return nil, pl, err
+	}
+	if e == nil {
+		var err error
+		if e, err = newPrefLib(&pl, opts); err != nil {
+			return nil, pl, err
+		}
+	}
+
+	if err := checkDeclared(pl.Metadata["NUMBER VOTERS"], voters); err != nil {
+		return nil, pl, fmt.Errorf("number of voters: %v", err)
+	}
+	if err := checkDeclared(pl.Metadata["NUMBER UNIQUE ORDERS"], unique); err != nil {
+		return nil, pl, fmt.Errorf("number of unique orders: %v", err)
+	}
+	return e, pl, nil
+}
+
+// newPrefLib returns the election described by the metadata of a PrefLib file.
+func newPrefLib(pl *PrefLib, opts []condorcet.Option) (*condorcet.Election, error) {
+	pl.Title = pl.Metadata["TITLE"]
+	pl.DataType = pl.Metadata["DATA TYPE"]
+	switch pl.DataType {
+	case SOC:
+	case SOI:
+		opts = append(opts, condorcet.AllowTruncation())
+	default:
+		return nil, fmt.Errorf("unsupported data type %q", pl.DataType)
+	}
+
+	n, err := strconv.Atoi(pl.Metadata["NUMBER ALTERNATIVES"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid number of alternatives %q", pl.Metadata["NUMBER ALTERNATIVES"])
+	}
+	if n < 2 {
+		return nil, fmt.Errorf("expecting at least 2 alternatives")
+	}
+	names := make([]string, n)
+	for c := range names {
+		names[c] = pl.Metadata["ALTERNATIVE NAME "+strconv.Itoa(c+1)]
+	}
+	return condorcet.NewNamed(names, opts...)
+}
+
+// checkDeclared checks a number declared in the metadata, if any.
+func checkDeclared(declared string, actual int) error {
+	if declared == "" {
+		return nil
+	}
+	if n, err := strconv.Atoi(declared); err != nil || n != actual {
+		return fmt.Errorf("declared %s, found %d", declared, actual)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package ballotio_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/batiazinga/condorcet/ballotio"
+)
+
+// TestReadPrefLib reads complete and incomplete datasets.
+func TestReadPrefLib(t *testing.T) {
+	data := `# FILE NAME: 00001-00000001.soc
+# TITLE: Condorcet's example
+# DATA TYPE: soc
+# NUMBER ALTERNATIVES: 3
+# NUMBER VOTERS: 60
+# NUMBER UNIQUE ORDERS: 6
+# ALTERNATIVE NAME 1: A
+# ALTERNATIVE NAME 2: B
+# ALTERNATIVE NAME 3: C
+23: 1,2,3
+19: 2,3,1
+16: 3,2,1
+2: 3,1,2
+0: 2,1,3
+0: 1,3,2
+`
+	e, pl, err := ballotio.ReadPrefLib(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pl.Title != "Condorcet's example" || pl.DataType != ballotio.SOC || e.NumVoters() != 60 {
+		t.Errorf("unexpected dataset %+v with %d voters", pl, e.NumVoters())
+	}
+	if w, ok := e.Result().WinnerName(); !ok || w != "B" {
+		t.Errorf("winner is (%s, %v) instead of B", w, ok)
+	}
+
+	// wrong number of voters
+	if _, _, err := ballotio.ReadPrefLib(strings.NewReader(strings.Replace(data, "VOTERS: 60", "VOTERS: 61", 1))); err == nil {
+		t.Error("wrong number of voters accepted")
+	}
+
+	// incomplete orders
+	data = strings.Replace(data, "soc", "soi", -1)
+	data = strings.Replace(data, "23: 1,2,3", "23: 1", 1)
+	if _, _, err := ballotio.ReadPrefLib(strings.NewReader(data)); err != nil {
+		t.Errorf("incomplete orders rejected: %v", err)
+	}
+	data = strings.Replace(data, "TYPE: soi", "TYPE: soc", 1)
+	if _, _, err := ballotio.ReadPrefLib(strings.NewReader(data)); err == nil {
+		t.Error("incomplete orders accepted in a complete dataset")
+	}
+}
@@ -0,0 +1,97 @@
+package condorcet
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// minParallelBatch is the smallest batch of ballots tallied in parallel by VoteAll.
+const minParallelBatch = 4096
+
+// VoteAll registers a batch of ballots, like Vote for each ballot in order.
+// It returns the number of accepted ballots and the error of the first rejected one (see VoteE);
+// rejected ballots do not stop the batch.
+//
+// Ballots are validated and written to the journal one after another,
+// then large batches are tallied in parallel, unless the profile is stored (see Exact)
+// or the sum matrix is sparse (see Sparse).
+// Observers are notified of every ballot once the batch is tallied (see Observe).
+func (e *Election) VoteAll(ballots [][]int) (accepted int, firstErr error) {
+	e.do(PhaseIngest, func() { accepted, firstErr = e.voteAll(ballots) })
+	return accepted, firstErr
+}
+
+// voteAll implements VoteAll.
+func (e *Election) voteAll(ballots [][]int) (accepted int, firstErr error) {
+	valid := make([][]int, 0, len(ballots))
+	var events []VoteEvent
+	observed := len(e.observers) > 0 || len(e.watchers) > 0
+	for _, received := range ballots {
+		ballot, err := e.check(received)
+		if err == nil {
+			// the valid ballots of the batch are not counted yet
+			err = e.overflows(uint(len(valid)) + 1)
+		}
+		if err == nil {
+			err = e.record(time.Time{}, ballot, 1)
+		}
+		if observed {
+			events = append(events, VoteEvent{Ballot: received, Count: 1, Err: err})
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		valid = append(valid, ballot)
+	}
+
+	e.castAll(valid)
+	for _, ev := range events {
+		e.notify(ev)
+	}
+	return len(valid), firstErr
+}
+
+// castAll counts the valid ballots once each, in parallel for large batches.
+func (e *Election) castAll(valid [][]int) {
+	if e.exact || e.sparse || len(valid) < minParallelBatch {
+		for _, ballot := range valid {
+			e.cast(ballot, 1)
+		}
+		return
+	}
+
+	if !e.initialized() {
+		e.init()
+	}
+	workers := runtime.GOMAXPROCS(0)
+	partials := make([]*Election, workers)
+	var wg sync.WaitGroup
+	for w := range partials {
+		partials[w] = &Election{n: e.n, width: e.width, truncation: e.truncation}
+		partials[w].init()
+		wg.Add(1)
+		go func(partial *Election, w int) {
+			defer wg.Done()
+			for k := w; k < len(valid); k += workers {
+				partial.add(valid[k], 1)
+			}
+		}(partials[w], w)
+	}
+	wg.Wait()
+
+	for _, partial := range partials {
+		for i := 0; i < e.num(); i++ {
+			for j := 0; j < e.num(); j++ {
+				if i != j {
+					e.m.add(i, j, partial.m.at(i, j))
+				}
+			}
+		}
+	}
+	e.ballots += len(valid)
+	e.voters += len(valid)
+}
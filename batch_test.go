@@ -0,0 +1,78 @@
+package condorcet_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestElection_VoteAll compares batches to ballots voted one by one.
+func TestElection_VoteAll(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, size := range []int{10, 10000} {
+		ballots := make([][]int, size)
+		for k := range ballots {
+			ballots[k] = rnd.Perm(4)
+		}
+		ballots[3] = []int{0, 0, 1, 2}
+		ballots[5] = []int{0, 1}
+
+		for _, opts := range [][]condorcet.Option{nil, {condorcet.Exact()}, {condorcet.AllowTruncation()}} {
+			batch, _ := condorcet.New(4, opts...)
+			one, _ := condorcet.New(4, opts...)
+			for _, ballot := range ballots {
+				one.Vote(ballot...)
+			}
+
+			accepted, err := batch.VoteAll(ballots)
+			if err != condorcet.ErrDuplicateCandidate {
+				t.Errorf("first error is %v", err)
+			}
+			if accepted != one.NumBallots() || batch.NumVoters() != one.NumVoters() {
+				t.Errorf("%d ballots accepted instead of %d", accepted, one.NumBallots())
+			}
+			if !batch.Result().Equal(one.Result()) {
+				t.Errorf("batch of %d ballots: tally differs", size)
+			}
+		}
+	}
+}
+
+// TestElection_VoteAllOverflow checks that a batch stops counting at the limit of the counters.
+func TestElection_VoteAllOverflow(t *testing.T) {
+	e, _ := condorcet.New(3, condorcet.TallyWidth(32))
+	e.VoteN(math.MaxUint32-1, 1, 0, 2)
+	accepted, err := e.VoteAll([][]int{{0, 1, 2}, {0, 1, 2}, {0, 1, 2}})
+	if accepted != 1 || err != condorcet.ErrOverflow {
+		t.Errorf("%d ballots accepted: %v", accepted, err)
+	}
+	if e.NumVoters() != math.MaxUint32 {
+		t.Errorf("%d voters", e.NumVoters())
+	}
+	if err := e.Result().Verify(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestElection_VoteAllNotify checks that observers and watchers are notified once the batch is tallied.
+func TestElection_VoteAllNotify(t *testing.T) {
+	events := 0
+	e, _ := condorcet.New(3, condorcet.Observe(func(ev condorcet.VoteEvent) { events++ }))
+	ch := e.Watch()
+	<-ch
+
+	e.VoteAll([][]int{{0, 1, 2}, {0, 0}, {0, 2, 1}})
+	if events != 3 {
+		t.Errorf("%d events instead of 3", events)
+	}
+	select {
+	case u := <-ch:
+		if !u.Exist || u.Winner != 0 || u.Voters != 2 {
+			t.Errorf("unexpected update %+v", u)
+		}
+	default:
+		t.Error("no update after the batch")
+	}
+}
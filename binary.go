@@ -0,0 +1,71 @@
+package condorcet
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+// BinaryVersion is the version of the binary encoding of elections and results,
+// written as its first byte (see Election.MarshalBinary).
+const BinaryVersion byte = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler, for fast checkpointing of large elections.
+// The encoding is a version byte followed by the gob encoding of the state persisted by MarshalJSON.
+func (e *Election) MarshalBinary() ([]byte, error) { return marshalState(e.state()) }
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// Like UnmarshalJSON, it checks the consistency of the state
+// and keeps the sanitizers, journal and hooks of the receiver.
+func (e *Election) UnmarshalBinary(data []byte) error {
+	doc, err := unmarshalState(data)
+	if err != nil {
+		return err
+	}
+	return e.restore(doc)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The result is encoded like an election, with its exclusions (see Exclusions).
+func (r Result) MarshalBinary() ([]byte, error) {
+	doc := r.e.state()
+	doc.Exclusions = r.e.exclusions
+	return marshalState(doc)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (r *Result) UnmarshalBinary(data []byte) error {
+	doc, err := unmarshalState(data)
+	if err != nil {
+		return err
+	}
+	e := new(Election)
+	if err := e.restore(doc); err != nil {
+		return err
+	}
+	r.e = e
+	return nil
+}
+
+// marshalState returns the binary encoding of the state.
+func marshalState(doc electionState) ([]byte, error) {
+	buf := bytes.NewBuffer([]byte{BinaryVersion})
+	if err := gob.NewEncoder(buf).Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalState decodes the binary encoding of a state.
+func unmarshalState(data []byte) (electionState, error) {
+	var doc electionState
+	if len(data) == 0 {
+		return doc, errors.New("empty binary encoding")
+	}
+	if data[0] != BinaryVersion {
+		return doc, fmt.Errorf("unsupported binary version %d", data[0])
+	}
+	err := gob.NewDecoder(bytes.NewReader(data[1:])).Decode(&doc)
+	return doc, err
+}
@@ -0,0 +1,73 @@
+package condorcet_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestElection_MarshalBinary checkpoints an election and a result.
+func TestElection_MarshalBinary(t *testing.T) {
+	for _, opts := range [][]condorcet.Option{nil, {condorcet.Exact()}} {
+		e, _ := condorcet.New(4, opts...)
+		e.VoteN(3, 0, 1, 3, 2)
+		e.VoteWeighted(2, 2, 0, 1, 3)
+
+		data, err := e.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data[0] != condorcet.BinaryVersion {
+			t.Errorf("first byte is %d instead of the version", data[0])
+		}
+		var resumed condorcet.Election
+		if err := resumed.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		if !resumed.Result().Equal(e.Result()) || resumed.NumBallots() != 4 {
+			t.Error("resumed election differs from the original one")
+		}
+
+		data[0] = condorcet.BinaryVersion + 1
+		if err := resumed.UnmarshalBinary(data); err == nil {
+			t.Error("unknown version accepted")
+		}
+	}
+
+	e, _ := condorcet.New(3)
+	e.Vote(2, 0, 1)
+	e.SetEligibilityHook(func(c int) error {
+		if c == 2 {
+			return errors.New("disqualified")
+		}
+		return nil
+	})
+	data, err := e.Result().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r condorcet.Result
+	if err := r.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if w, ok := r.Winner(); !ok || w != 0 || len(r.Exclusions()) != 1 {
+		t.Errorf("winner is (%d, %v) with exclusions %v", w, ok, r.Exclusions())
+	}
+}
+
+// TestResult_UnmarshalBinary_noVote decodes an election without vote as a result.
+func TestResult_UnmarshalBinary_noVote(t *testing.T) {
+	e, _ := condorcet.New(3)
+	data, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r condorcet.Result
+	if err := r.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if _, exist := r.Winner(); exist || r.NumVoters() != 0 {
+		t.Error("winner without vote")
+	}
+}
@@ -0,0 +1,49 @@
+package condorcet
+
+// Black returns the outcome of Black's method:
+// the Condorcet winner if there is one, and otherwise the candidate
+// with the highest Borda score (see Baldwin).
+//
+// It returns the winner, false if several candidates tie for the highest Borda score,
+// and whether the Condorcet criterion decided the outcome, rather than the Borda count.
+// Candidates excluded by the eligibility hook cannot win.
+//
+// Like Winner, it reports no valid winner if "none of the above" wins (see NoneOfTheAbove):
+// it returns 0 and false if it is the Condorcet winner or has the highest Borda score, even tied.
+func (r Result) Black() (w int, unique bool, condorcet bool) {
+	if w, exist := r.winner(); exist {
+		w, unique = r.elect([]int{w})
+		return w, unique, true
+	}
+
+	var scores []int
+	r.e.do(PhaseBlack, func() { scores = r.bordaScores() })
+	rk := rankByScore(r.e.candidates(), scores)
+	if len(rk) == 0 {
+		return 0, false, false
+	}
+	w, unique = r.elect(rk[0])
+	return w, unique, false
+}
+
+// bordaScores returns the Borda scores of the eligible candidates.
+func (r Result) bordaScores() []int {
+	running := make([]bool, r.e.num())
+	for _, c := range r.e.candidates() {
+		running[c] = true
+	}
+	return r.borda(running)
+}
+
+func init() {
+	completions = append(completions, completion{
+		name: "Black",
+		winners: func(r Result) []int {
+			rk := rankByScore(r.e.candidates(), r.bordaScores())
+			if len(rk) == 0 {
+				return nil
+			}
+			return rk[0]
+		},
+	})
+}
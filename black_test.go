@@ -0,0 +1,62 @@
+package condorcet_test
+
+import (
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_Black checks Black's method on the testcases.
+func TestResult_Black(t *testing.T) {
+	testcases := []struct {
+		label     string
+		winner    int
+		unique    bool
+		condorcet bool
+	}{
+		{"Condorcet's example", 2, true, true},
+		{"4 candidates", 3, true, true},
+		{"paradoxe", 1, true, false}, // Borda scores are 58, 69 and 53
+		{"no vote", 0, false, false},
+	}
+
+	for _, tc := range testcases {
+		w, unique, condorcet := result(t, tc.label).Black()
+		if w != tc.winner || unique != tc.unique || condorcet != tc.condorcet {
+			t.Errorf(
+				"%s: outcome (%d, %v, %v) instead of (%d, %v, %v)",
+				tc.label, w, unique, condorcet, tc.winner, tc.unique, tc.condorcet,
+			)
+		}
+	}
+}
+
+// TestResult_BlackNOTA checks that "none of the above" is never elected by Black's method.
+func TestResult_BlackNOTA(t *testing.T) {
+	// none of the above is the Condorcet winner
+	e, _ := condorcet.New(2, condorcet.NoneOfTheAbove())
+	e.Vote(2, 0, 1)
+	e.Vote(2, 1, 0)
+	e.Vote(0, 2, 1)
+	if w, unique, condorcet := e.Result().Black(); unique || !condorcet {
+		t.Errorf("Condorcet winner none of the above: outcome (%d, %v, %v)", w, unique, condorcet)
+	}
+
+	// cycle 0 > 1 > 2 > 0 where none of the above has the highest Borda score
+	e, _ = condorcet.New(2, condorcet.NoneOfTheAbove())
+	e.VoteN(3, 2, 0, 1)
+	e.VoteN(2, 0, 1, 2)
+	e.VoteN(2, 1, 2, 0)
+	if _, exist := e.Result().Winner(); exist {
+		t.Fatal("unexpected Condorcet winner")
+	}
+	if w, unique, condorcet := e.Result().Black(); unique || condorcet {
+		t.Errorf("Borda winner none of the above: outcome (%d, %v, %v)", w, unique, condorcet)
+	}
+
+	// a real candidate wins the Borda count
+	e.VoteN(2, 0, 1, 2)
+	if w, unique, condorcet := e.Result().Black(); w != 0 || !unique || condorcet {
+		t.Errorf("unexpected outcome (%d, %v, %v)", w, unique, condorcet)
+	}
+}
@@ -0,0 +1,306 @@
+package condorcet
+
+import "errors"
+
+// MergeCandidates folds the candidates from into the candidate into,
+// e.g. when the same person was imported under two spellings.
+// The merged candidate takes, on each ballot, the best position of the folded candidates.
+//
+// Remaining candidates are renumbered in increasing order of their former index
+// and at least 2 of them must remain. The number of voters is preserved.
+//
+// The sum matrix does not tell which of the folded candidates each voter preferred,
+// so the merge is exact only if the election stores its full profile (see Exact).
+// Otherwise, the support of the merged candidate against another candidate is the
+// best support among the folded candidates, which is a lower bound.
+func (e *Election) MergeCandidates(into int, from ...int) error {
+	if e.journal != nil {
+		return ErrJournaled
+	}
+	n := e.num()
+	if into < 0 || into >= n {
+		return errors.New("candidate out of range")
+	}
+	folded := make([]bool, n)
+	for _, c := range from {
+		if c < 0 || c >= n {
+			return errors.New("candidate out of range")
+		}
+		if c == into || folded[c] {
+			return errors.New("candidate is merged twice")
+		}
+		folded[c] = true
+	}
+	if nota, ok := e.NOTA(); ok && (into == nota || folded[nota]) {
+		return errors.New("none of the above cannot be merged")
+	}
+	if n-len(from) < 2 {
+		return errors.New("expecting at least 2 remaining candidates")
+	}
+
+	defer e.publish()
+
+	// new index of the candidates
+	index := make([]int, n)
+	var next int
+	for c := range index {
+		if !folded[c] {
+			index[c] = next
+			next++
+		}
+	}
+	for _, c := range from {
+		index[c] = index[into]
+	}
+
+	if e.names != nil {
+		names := make([]string, next)
+		for c := range e.names {
+			if !folded[c] {
+				names[index[c]] = e.names[c]
+			}
+		}
+		e.names = names
+	}
+	if e.meta != nil {
+		meta := make([]Metadata, next)
+		for c := range e.meta {
+			if !folded[c] {
+				meta[index[c]] = e.meta[c]
+			}
+		}
+		e.meta = meta
+	}
+	for id, ballot := range e.provisional {
+		e.provisional[id] = reindex(ballot, index, next)
+	}
+	for k := range e.stamped {
+		e.stamped[k].ballot = reindex(e.stamped[k].ballot, index, next)
+	}
+	if !e.initialized() {
+		e.n = next - 2
+		return nil
+	}
+
+	old := e.clone()
+	e.n = next - 2
+	e.init()
+
+	if e.exact {
+		for code, count := range old.p {
+			if count == 0 {
+				continue
+			}
+			ballot, _ := DecodeBallot(uint64(code), n)
+			code, _ := EncodeBallot(reindex(ballot, index, next))
+			e.p[code] += count
+		}
+		e.dirty = true
+		return nil
+	}
+
+	group := append([]int{into}, from...)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == j || folded[i] || folded[j] {
+				continue
+			}
+			switch {
+			case i == into:
+				// best support of the group against j
+				var best int
+				for _, g := range group {
+					if s := old.m.at(g, j); s > best {
+						best = s
+					}
+				}
+				e.m.set(index[i], index[j], best)
+			case j == into:
+				// least support of i against the group
+				least := old.m.at(i, into)
+				for _, g := range from {
+					if s := old.m.at(i, g); s < least {
+						least = s
+					}
+				}
+				e.m.set(index[i], index[j], least)
+			default:
+				e.m.set(index[i], index[j], old.m.at(i, j))
+			}
+		}
+	}
+	return nil
+}
+
+// Remap renumbers the candidates: candidate i becomes candidate perm[i].
+// perm must be a permutation of the candidates.
+// "None of the above" cannot be renumbered.
+//
+// It is useful to reorder candidates for display
+// or to follow the numbering of imported data.
+func (e *Election) Remap(perm []int) error {
+	if e.journal != nil {
+		return ErrJournaled
+	}
+	if !isTotalOrder(perm, e.num()) {
+		return errors.New("expecting a permutation of the candidates")
+	}
+	if nota, ok := e.NOTA(); ok && perm[nota] != nota {
+		return errors.New("none of the above cannot be renumbered")
+	}
+	defer e.publish()
+	if e.names != nil {
+		names := make([]string, len(e.names))
+		for c, name := range e.names {
+			names[perm[c]] = name
+		}
+		e.names = names
+	}
+	if e.meta != nil {
+		meta := make([]Metadata, e.num())
+		for c, m := range e.meta {
+			meta[perm[c]] = m
+		}
+		e.meta = meta
+	}
+	for id, ballot := range e.provisional {
+		e.provisional[id] = reindex(ballot, perm, e.num())
+	}
+	for k := range e.stamped {
+		e.stamped[k].ballot = reindex(e.stamped[k].ballot, perm, e.num())
+	}
+	if !e.initialized() {
+		return nil
+	}
+
+	old := e.clone()
+	e.init()
+
+	if e.exact {
+		for code, count := range old.p {
+			if count == 0 {
+				continue
+			}
+			ballot, _ := DecodeBallot(uint64(code), e.num())
+			code, _ := EncodeBallot(reindex(ballot, perm, e.num()))
+			e.p[code] = count
+		}
+		e.dirty = true
+		return nil
+	}
+
+	for i := 0; i < e.num(); i++ {
+		for j := 0; j < e.num(); j++ {
+			if i != j {
+				e.m.set(perm[i], perm[j], old.m.at(i, j))
+			}
+		}
+	}
+	return nil
+}
+
+// reindex returns the ballot over n candidates where candidate c becomes index[c].
+// Only the first occurrence of a new index is kept.
+func reindex(ballot, index []int, n int) []int {
+	reindexed := make([]int, 0, n)
+	seen := make([]bool, n)
+	for _, c := range ballot {
+		if !seen[index[c]] {
+			seen[index[c]] = true
+			reindexed = append(reindexed, index[c])
+		}
+	}
+	return reindexed
+}
+
+// AddCandidate adds a candidate to the election, e.g. when nominations arrive while voting is open,
+// and returns its index. It is the number of candidates before the addition:
+// "none of the above", if any, is moved to the end (see NoneOfTheAbove).
+//
+// Previously cast ballots rank the new candidate last,
+// i.e. every voter so far prefers every other candidate to it.
+// It is therefore refused once truncated ballots were cast (see AllowTruncation),
+// since the sum matrix does not tell which candidates they ranked.
+// The addition is written to the journal (see Journal).
+func (e *Election) AddCandidate() (int, error) {
+	if e.truncation && e.voters > 0 {
+		return 0, errors.New("cannot add a candidate after truncated ballots")
+	}
+	if e.exact && e.num() >= MaxExactCandidates {
+		return 0, errors.New("too many candidates for an exact profile")
+	}
+	if e.journal != nil {
+		if err := e.commit([]byte("+\n")); err != nil {
+			return 0, err
+		}
+	}
+	added := e.addCandidate()
+	e.publish()
+	return added, nil
+}
+
+// addCandidate implements AddCandidate.
+func (e *Election) addCandidate() int {
+	n := e.num()
+	added := n
+	index := make([]int, n)
+	for c := range index {
+		index[c] = c
+	}
+	if e.nota {
+		added = n - 1
+		index[n-1] = n
+	}
+
+	// complete ballots rank the new candidate last
+	extend := func(ballot []int) []int {
+		extended := reindex(ballot, index, n+1)
+		if len(ballot) == n {
+			extended = append(extended, added)
+		}
+		return extended
+	}
+	for id, ballot := range e.provisional {
+		e.provisional[id] = extend(ballot)
+	}
+	for k := range e.stamped {
+		e.stamped[k].ballot = extend(e.stamped[k].ballot)
+	}
+	if e.names != nil {
+		e.names = append(e.names[:added:added], append([]string{""}, e.names[added:]...)...)
+	}
+	if e.meta != nil {
+		e.meta = append(e.meta[:added:added], append([]Metadata{{}}, e.meta[added:]...)...)
+	}
+	if !e.initialized() {
+		e.n++
+		return added
+	}
+
+	old := e.clone()
+	e.n++
+	e.init()
+
+	if e.exact {
+		for code, count := range old.p {
+			if count == 0 {
+				continue
+			}
+			ballot, _ := DecodeBallot(uint64(code), n)
+			code, _ := EncodeBallot(extend(ballot))
+			e.p[code] = count
+		}
+		e.dirty = true
+		return added
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j {
+				e.m.set(index[i], index[j], old.m.at(i, j))
+			}
+		}
+		e.m.set(index[i], added, e.voters)
+	}
+	return added
+}
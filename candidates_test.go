@@ -0,0 +1,143 @@
+package condorcet_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestElection_MergeCandidates merges duplicate candidates
+// and checks the outcome with and without the full profile.
+func TestElection_MergeCandidates(t *testing.T) {
+	// candidates 1 and 3 are the same person
+	ballots := [][]int{
+		{1, 0, 2, 3},
+		{3, 2, 1, 0},
+		{0, 2, 3, 1},
+		{2, 3, 0, 1},
+		{2, 0, 1, 3},
+	}
+
+	for _, exact := range []bool{false, true} {
+		var opts []condorcet.Option
+		if exact {
+			opts = append(opts, condorcet.Exact())
+		}
+		e, _ := condorcet.New(4, opts...)
+		for _, ballot := range ballots {
+			e.Vote(ballot...)
+		}
+
+		if err := e.MergeCandidates(1, 3); err != nil {
+			t.Fatalf("merge failed: %v", err)
+		}
+		if e.NumVoters() != len(ballots) {
+			t.Errorf("%d voters after merge instead of %d", e.NumVoters(), len(ballots))
+		}
+		r := e.Result()
+		if r.NumCandidates() != 3 {
+			t.Errorf("%d candidates after merge instead of 3", r.NumCandidates())
+		}
+		// 2 beats 0 (3-2) and the merged 1 (3-2 in the exact case)
+		if w, exist := r.Winner(); !exist || w != 2 {
+			t.Errorf("unexpected winner (%d, %v) with exact=%v", w, exist, exact)
+		}
+		if !e.Vote(2, 1, 0) {
+			t.Error("3-candidate ballot rejected after merge")
+		}
+	}
+
+	e, _ := condorcet.New(3)
+	if err := e.MergeCandidates(0, 1, 2); err == nil {
+		t.Error("merging down to a single candidate did not fail")
+	}
+	if err := e.MergeCandidates(0, 0); err == nil {
+		t.Error("merging a candidate into itself did not fail")
+	}
+}
+
+// TestElection_Remap renumbers the candidates and checks the winner follows.
+func TestElection_Remap(t *testing.T) {
+	for _, exact := range []bool{false, true} {
+		var opts []condorcet.Option
+		if exact {
+			opts = append(opts, condorcet.Exact())
+		}
+		e, _ := condorcet.New(3, opts...)
+		e.Vote(0, 1, 2)
+		e.Vote(0, 2, 1)
+		e.Vote(1, 0, 2)
+
+		if err := e.Remap([]int{2, 0, 1}); err != nil {
+			t.Fatalf("remap failed: %v", err)
+		}
+		if w, exist := e.Result().Winner(); !exist || w != 2 {
+			t.Errorf("unexpected winner (%d, %v) with exact=%v", w, exist, exact)
+		}
+		if e.NumVoters() != 3 {
+			t.Errorf("%d voters after remap instead of 3", e.NumVoters())
+		}
+	}
+
+	e, _ := condorcet.New(3)
+	if err := e.Remap([]int{0, 0, 1}); err == nil {
+		t.Error("remapping with a non permutation did not fail")
+	}
+}
+
+// TestElection_AddCandidate checks that previous ballots rank a new candidate last.
+func TestElection_AddCandidate(t *testing.T) {
+	for _, opts := range [][]condorcet.Option{nil, {condorcet.Exact()}} {
+		var journal bytes.Buffer
+		e, _ := condorcet.New(3, append(opts, condorcet.Journal(&journal))...)
+		e.VoteN(2, 0, 1, 2)
+		e.Vote(2, 1, 0)
+		id, _ := e.VoteProvisional(1, 0, 2)
+
+		if c, err := e.AddCandidate(); err != nil || c != 3 {
+			t.Fatalf("added candidate (%d, %v) instead of 3", c, err)
+		}
+		if e.Vote(0, 1, 2) {
+			t.Error("ballot without the new candidate accepted")
+		}
+		e.Accept(id) // 1 > 0 > 2 > 3
+		e.VoteN(5, 3, 0, 1, 2)
+
+		// 3 beats every other candidate by 5 to 4
+		r := e.Result()
+		if m, _ := r.Matchup(3, 2); m.ForA != 5 || m.ForB != 4 {
+			t.Errorf("3 against 2 is %d to %d instead of 5 to 4", m.ForA, m.ForB)
+		}
+		if w, ok := r.Winner(); !ok || w != 3 {
+			t.Errorf("winner is (%d, %v) instead of 3", w, ok)
+		}
+
+		replayed, _ := condorcet.New(3, opts...)
+		if _, err := replayed.Replay(&journal); err != nil {
+			t.Fatal(err)
+		}
+		if !replayed.Result().Equal(r) {
+			t.Error("replayed tally differs from the original one")
+		}
+	}
+
+	// none of the above stays last
+	e, _ := condorcet.NewNamed([]string{"Alice", "Bob"}, condorcet.NoneOfTheAbove())
+	e.Vote(0, 2, 1)
+	if c, _ := e.AddCandidate(); c != 2 {
+		t.Errorf("added candidate %d instead of 2", c)
+	}
+	if nota, _ := e.NOTA(); nota != 3 || e.Name(3) != condorcet.NOTAName || e.Name(2) != "2" {
+		t.Errorf("unexpected candidates %q, %q after addition", e.Name(2), e.Name(3))
+	}
+	if m, _ := e.Result().Matchup(3, 2); m.ForA != 1 {
+		t.Error("previous ballot does not rank none of the above above the new candidate")
+	}
+
+	e, _ = condorcet.New(3, condorcet.AllowTruncation())
+	e.Vote(0)
+	if _, err := e.AddCandidate(); err == nil {
+		t.Error("candidate added after truncated ballots")
+	}
+}
@@ -0,0 +1,361 @@
+// Package chat runs Condorcet polls from chat slash commands such as
+//
+//	/vote Alice > Bob > Carol
+//
+// A Poll maps chat users to voter tokens so that each user votes once,
+// tallies the ballots into an Election and formats the outcome as a chat message.
+// Handler serves Slack-style slash-command payloads signed with the app's signing secret,
+// DiscordHandler serves Discord interactions signed with the application's public key.
+package chat
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// Poll is an election run from a chat.
+// It is safe for concurrent use.
+type Poll struct {
+	// Token maps a chat user to a voter token.
+	// Users sharing a token share a single vote.
+	// If nil, the user identifier is the token.
+	Token func(user string) string
+
+	mu         sync.Mutex
+	candidates []string
+	election   *condorcet.Election
+	voted      map[string]bool // voter tokens which already voted
+}
+
+// NewPoll returns a poll between the named candidates.
+// Names are matched case-insensitively in ballots and must be distinct.
+func NewPoll(candidates ...string) (*Poll, error) {
+	seen := make(map[string]bool)
+	for _, name := range candidates {
+		key := normalize(name)
+		if key == "" {
+			return nil, errors.New("empty candidate name")
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate candidate %q", name)
+		}
+		seen[key] = true
+	}
+
+	e, err := condorcet.New(len(candidates))
+	if err != nil {
+		return nil, err
+	}
+	return &Poll{
+		candidates: candidates,
+		election:   e,
+		voted:      make(map[string]bool),
+	}, nil
+}
+
+// normalize returns the key used to match candidate names.
+func normalize(name string) string { return strings.ToLower(strings.TrimSpace(name)) }
+
+// ParseBallot parses a ranking of the form "A > B > C".
+// All candidates must be ranked.
+func (p *Poll) ParseBallot(text string) ([]int, error) {
+	names := strings.Split(text, ">")
+	if len(names) != len(p.candidates) {
+		return nil, fmt.Errorf("expecting a ranking of the %d candidates: %s", len(p.candidates), strings.Join(p.candidates, " > "))
+	}
+
+	ballot := make([]int, len(names))
+	for i, name := range names {
+		ballot[i] = -1
+		for c, candidate := range p.candidates {
+			if normalize(name) == normalize(candidate) {
+				ballot[i] = c
+				break
+			}
+		}
+		if ballot[i] < 0 {
+			return nil, fmt.Errorf("unknown candidate %q", strings.TrimSpace(name))
+		}
+	}
+	return ballot, nil
+}
+
+// Vote records the ballot of the user, given as text.
+// A user can vote only once.
+func (p *Poll) Vote(user, text string) error {
+	ballot, err := p.ParseBallot(text)
+	if err != nil {
+		return err
+	}
+
+	token := user
+	if p.Token != nil {
+		token = p.Token(user)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.voted[token] {
+		return errors.New("you already voted")
+	}
+	if !p.election.Vote(ballot...) {
+		return errors.New("each candidate must be ranked exactly once")
+	}
+	p.voted[token] = true
+	return nil
+}
+
+// Message returns the current outcome of the poll as a chat message.
+func (p *Poll) Message() string {
+	p.mu.Lock()
+	r := p.election.Result()
+	p.mu.Unlock()
+
+	voters := fmt.Sprintf("%d voter", r.NumVoters())
+	if r.NumVoters() != 1 {
+		voters += "s"
+	}
+	if w, exist := r.Winner(); exist {
+		return fmt.Sprintf("Winner: *%s* (%s)", p.candidates[w], voters)
+	}
+	return fmt.Sprintf("No Condorcet winner (%s)", voters)
+}
+
+// reply returns the reply to a command of the user:
+// the text "results" replies publicly with the current outcome,
+// any other text is a ballot, acknowledged privately.
+func (p *Poll) reply(user, text string) (msg string, public bool) {
+	if strings.EqualFold(text, "results") {
+		return p.Message(), true
+	}
+	if err := p.Vote(user, text); err != nil {
+		return "Ballot rejected: " + err.Error(), false
+	}
+	return "Ballot recorded: " + text, false
+}
+
+// MaxSkew is the maximum age of a request accepted by Handler.
+// Older requests are rejected to prevent replays.
+const MaxSkew = 5 * time.Minute
+
+// maxPayload is the maximum size in bytes of a slash-command payload.
+const maxPayload = 1 << 20
+
+// Handler returns a handler for Slack-style slash commands.
+// The payload is a form with the user_id and text fields.
+//
+// Requests must be signed with secret, the signing secret of the app:
+// the X-Slack-Signature header is checked against the X-Slack-Request-Timestamp header and the payload,
+// and requests older than MaxSkew are rejected.
+//
+// The text "results" replies with the current outcome.
+// Any other text is a ballot.
+func (p *Poll) Handler(secret []byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, ok := readPost(w, req)
+		if !ok {
+			return
+		}
+		if err := verify(secret, req.Header, body, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		user, text := form.Get("user_id"), strings.TrimSpace(form.Get("text"))
+		if user == "" {
+			http.Error(w, "missing user_id", http.StatusBadRequest)
+			return
+		}
+
+		reply := struct {
+			ResponseType string `json:"response_type"`
+			Text         string `json:"text"`
+		}{ResponseType: "ephemeral"}
+		var public bool
+		if reply.Text, public = p.reply(user, text); public {
+			reply.ResponseType = "in_channel"
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(reply)
+	})
+}
+
+// readPost returns the payload of a POST request.
+// Otherwise, or if the payload cannot be read, it replies with an error and returns false.
+func readPost(w http.ResponseWriter, req *http.Request) ([]byte, bool) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return nil, false
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(req.Body, maxPayload))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return body, true
+}
+
+// Types of the Discord interactions and of their responses.
+const (
+	discordPing    = 1  // interaction: endpoint check
+	discordCommand = 2  // interaction: application command
+	discordPong    = 1  // response: acknowledgement of a ping
+	discordMessage = 4  // response: message in the channel
+	discordPrivate = 64 // message flag: only visible to the user
+)
+
+// discordInteraction is the part of a Discord interaction used by DiscordHandler.
+type discordInteraction struct {
+	Type int `json:"type"`
+	Data struct {
+		Name    string `json:"name"`
+		Options []struct {
+			Value interface{} `json:"value"`
+		} `json:"options"`
+	} `json:"data"`
+	Member *struct {
+		User discordUser `json:"user"`
+	} `json:"member"` // in a guild
+	User *discordUser `json:"user"` // in a direct message
+}
+
+// discordUser is a Discord user.
+type discordUser struct {
+	ID string `json:"id"`
+}
+
+// DiscordHandler returns a handler for Discord interactions:
+// a "results" command replies with the current outcome,
+// any other command is a ballot, given by its first option, e.g. /vote ranking:A > B > C.
+// Pings are acknowledged.
+//
+// Requests must be signed with the private key of the application, whose public key is key:
+// the X-Signature-Ed25519 header is checked against the X-Signature-Timestamp header and the payload,
+// and requests older than MaxSkew are rejected.
+func (p *Poll) DiscordHandler(key ed25519.PublicKey) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, ok := readPost(w, req)
+		if !ok {
+			return
+		}
+		if err := verifyDiscord(key, req.Header, body, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		var in discordInteraction
+		if err := json.Unmarshal(body, &in); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		type message struct {
+			Content string `json:"content"`
+			Flags   int    `json:"flags,omitempty"`
+		}
+		var reply struct {
+			Type int      `json:"type"`
+			Data *message `json:"data,omitempty"`
+		}
+		switch in.Type {
+		case discordPing:
+			reply.Type = discordPong
+		case discordCommand:
+			var user string
+			if in.Member != nil {
+				user = in.Member.User.ID
+			} else if in.User != nil {
+				user = in.User.ID
+			}
+			if user == "" {
+				http.Error(w, "missing user", http.StatusBadRequest)
+				return
+			}
+			text := in.Data.Name
+			if len(in.Data.Options) > 0 {
+				text, _ = in.Data.Options[0].Value.(string)
+			}
+
+			reply.Type = discordMessage
+			reply.Data = &message{Flags: discordPrivate}
+			var public bool
+			if reply.Data.Content, public = p.reply(user, strings.TrimSpace(text)); public {
+				reply.Data.Flags = 0
+			}
+		default:
+			http.Error(w, fmt.Sprintf("unsupported interaction type %d", in.Type), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(reply)
+	})
+}
+
+// Sign returns the signature of a payload sent at time t, as expected in the X-Slack-Signature header.
+func Sign(secret []byte, t time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "v0:%d:", t.Unix())
+	mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// verify checks the signature of a request received at time now.
+func verify(secret []byte, h http.Header, body []byte, now time.Time) error {
+	if len(secret) == 0 {
+		return errors.New("missing signing secret")
+	}
+	ts, err := strconv.ParseInt(h.Get("X-Slack-Request-Timestamp"), 10, 64)
+	if err != nil {
+		return errors.New("invalid request timestamp")
+	}
+	t := time.Unix(ts, 0)
+	if skew := now.Sub(t); skew > MaxSkew || skew < -MaxSkew {
+		return errors.New("stale request")
+	}
+	if !hmac.Equal([]byte(h.Get("X-Slack-Signature")), []byte(Sign(secret, t, body))) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// verifyDiscord checks the signature of a Discord interaction received at time now.
+func verifyDiscord(key ed25519.PublicKey, h http.Header, body []byte, now time.Time) error {
+	if len(key) != ed25519.PublicKeySize {
+		return errors.New("invalid public key")
+	}
+	timestamp := h.Get("X-Signature-Timestamp")
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("invalid request timestamp")
+	}
+	if skew := now.Sub(time.Unix(ts, 0)); skew > MaxSkew || skew < -MaxSkew {
+		return errors.New("stale request")
+	}
+	sig, err := hex.DecodeString(h.Get("X-Signature-Ed25519"))
+	if err != nil || !ed25519.Verify(key, append([]byte(timestamp), body...), sig) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
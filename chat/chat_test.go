@@ -0,0 +1,177 @@
+package chat_test
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/batiazinga/condorcet/chat"
+)
+
+// TestPoll_Vote asserts that ballots are parsed, tallied and that users vote once.
+func TestPoll_Vote(t *testing.T) {
+	p, err := chat.NewPoll("Alice", "Bob", "Carol")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.Vote("u1", "bob > alice > carol"); err != nil {
+		t.Fatalf("valid ballot rejected: %v", err)
+	}
+	if err := p.Vote("u1", "Alice > Bob > Carol"); err == nil {
+		t.Error("second vote of the same user accepted")
+	}
+	if err := p.Vote("u2", "Alice > Bob"); err == nil {
+		t.Error("partial ballot accepted")
+	}
+	if err := p.Vote("u2", "Alice > Bob > Dave"); err == nil {
+		t.Error("unknown candidate accepted")
+	}
+
+	if msg := p.Message(); msg != "Winner: *Bob* (1 voter)" {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
+
+// signed returns a slash-command request signed with secret at time t.
+func signed(secret []byte, t time.Time, user, text string) *http.Request {
+	body := url.Values{"user_id": {user}, "text": {text}}.Encode()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", strconv.FormatInt(t.Unix(), 10))
+	req.Header.Set("X-Slack-Signature", chat.Sign(secret, t, []byte(body)))
+	return req
+}
+
+// TestPoll_Handler asserts that slash-command payloads are handled.
+func TestPoll_Handler(t *testing.T) {
+	secret := []byte("secret")
+	p, _ := chat.NewPoll("A", "B")
+	h := p.Handler(secret)
+
+	post := func(user, text string) string {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, signed(secret, time.Now(), user, text))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("unexpected status %d", rec.Code)
+		}
+		return rec.Body.String()
+	}
+
+	if body := post("u1", "B>A"); !strings.Contains(body, "Ballot recorded") {
+		t.Errorf("unexpected reply to a ballot: %s", body)
+	}
+	if body := post("u2", "results"); !strings.Contains(body, "Winner: *B*") {
+		t.Errorf("unexpected reply to results: %s", body)
+	}
+}
+
+// TestPoll_HandlerSignature asserts that unsigned, forged and stale requests are rejected.
+func TestPoll_HandlerSignature(t *testing.T) {
+	secret := []byte("secret")
+	p, _ := chat.NewPoll("A", "B")
+	h := p.Handler(secret)
+
+	unsigned := signed(secret, time.Now(), "u1", "B>A")
+	unsigned.Header.Del("X-Slack-Signature")
+	for name, req := range map[string]*http.Request{
+		"unsigned": unsigned,
+		"forged":   signed([]byte("forged"), time.Now(), "u1", "B>A"),
+		"stale":    signed(secret, time.Now().Add(-2*chat.MaxSkew), "u1", "B>A"),
+		"future":   signed(secret, time.Now().Add(2*chat.MaxSkew), "u1", "B>A"),
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s request: unexpected status %d", name, rec.Code)
+		}
+	}
+	if msg := p.Message(); msg != "No Condorcet winner (0 voters)" {
+		t.Errorf("rejected requests were tallied: %q", msg)
+	}
+
+	// tampered payload
+	req := signed(secret, time.Now(), "u1", "B>A")
+	req.Body = ioutil.NopCloser(strings.NewReader(url.Values{"user_id": {"u2"}, "text": {"B>A"}}.Encode()))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("tampered request: unexpected status %d", rec.Code)
+	}
+}
+
+// signedDiscord returns a Discord interaction signed with key at time t.
+func signedDiscord(key ed25519.PrivateKey, t time.Time, payload string) *http.Request {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Signature-Timestamp", timestamp)
+	req.Header.Set("X-Signature-Ed25519", hex.EncodeToString(ed25519.Sign(key, []byte(timestamp+payload))))
+	return req
+}
+
+// TestPoll_DiscordHandler asserts that Discord pings and commands are handled.
+func TestPoll_DiscordHandler(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	p, _ := chat.NewPoll("A", "B")
+	h := p.DiscordHandler(pub)
+
+	post := func(payload string) string {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, signedDiscord(priv, time.Now(), payload))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("unexpected status %d for %s", rec.Code, payload)
+		}
+		return strings.TrimSpace(rec.Body.String())
+	}
+
+	if body := post(`{"type":1}`); body != `{"type":1}` {
+		t.Errorf("unexpected reply to a ping: %s", body)
+	}
+	vote := `{"type":2,"data":{"name":"vote","options":[{"name":"ranking","value":"B>A"}]},"member":{"user":{"id":"u1"}}}`
+	if body := post(vote); !strings.Contains(body, `"type":4`) || !strings.Contains(body, "Ballot recorded") || !strings.Contains(body, `"flags":64`) {
+		t.Errorf("unexpected reply to a ballot: %s", body)
+	}
+	if body := post(vote); !strings.Contains(body, "already voted") {
+		t.Errorf("unexpected reply to a second ballot: %s", body)
+	}
+	if body := post(`{"type":2,"data":{"name":"results"},"user":{"id":"u2"}}`); !strings.Contains(body, "Winner: *B*") || strings.Contains(body, "flags") {
+		t.Errorf("unexpected reply to results: %s", body)
+	}
+}
+
+// TestPoll_DiscordHandlerSignature asserts that unsigned, forged and stale interactions are rejected.
+func TestPoll_DiscordHandlerSignature(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	_, forged, _ := ed25519.GenerateKey(nil)
+	p, _ := chat.NewPoll("A", "B")
+	h := p.DiscordHandler(pub)
+
+	vote := `{"type":2,"data":{"name":"vote","options":[{"value":"B>A"}]},"member":{"user":{"id":"u1"}}}`
+	unsigned := signedDiscord(priv, time.Now(), vote)
+	unsigned.Header.Del("X-Signature-Ed25519")
+	tampered := signedDiscord(priv, time.Now(), vote)
+	tampered.Body = ioutil.NopCloser(strings.NewReader(strings.Replace(vote, "u1", "u2", 1)))
+	for name, req := range map[string]*http.Request{
+		"unsigned": unsigned,
+		"forged":   signedDiscord(forged, time.Now(), vote),
+		"stale":    signedDiscord(priv, time.Now().Add(-2*chat.MaxSkew), vote),
+		"tampered": tampered,
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s request: unexpected status %d", name, rec.Code)
+		}
+	}
+	if msg := p.Message(); msg != "No Condorcet winner (0 voters)" {
+		t.Errorf("rejected requests were tallied: %q", msg)
+	}
+}
@@ -0,0 +1,113 @@
+package condorcet
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CloneEffect is the outcome of a method before and after the cloning of a candidate
+// (see Result.CloneEffects).
+type CloneEffect struct {
+	Winner int  // winner of the actual ballots
+	Unique bool // false if several candidates tie
+
+	NewWinner int  // winner with the clone, which is the last candidate
+	NewUnique bool // false if several candidates tie with the clone
+
+	// Changed reports whether the clone changes the outcome.
+	// The clone of the winner winning instead of it is not a change.
+	Changed bool
+}
+
+// CloneEffects adds a clone of the candidate to the election,
+// ranked right after the original on every ballot,
+// and reports whether the winner of each method changes (see Methods).
+// A method independent of clones never changes its winner;
+// the effects help to choose a completion method.
+// The clone is candidate n for an election of n candidates.
+//
+// The full profile of the election is cloned if it is stored (see Exact)
+// and if the clone does not exceed MaxExactCandidates;
+// otherwise only the pairwise matrix is, and the methods requiring the ballots fail.
+// Elections allowing truncated ballots or ties, or with "none of the above", cannot be cloned.
+func (r Result) CloneEffects(candidate int, methods map[string]Method) (map[string]CloneEffect, error) {
+	if candidate < 0 || candidate >= r.e.num() || !r.e.eligible(candidate) {
+		return nil, errors.New("candidate out of range or excluded")
+	}
+	if r.e.truncation || r.e.ties || r.e.nota {
+		return nil, errors.New("cannot clone candidates of incomplete ballots or with none of the above")
+	}
+
+	cloned := r.cloned(candidate)
+	clone := r.e.num()
+	effects := make(map[string]CloneEffect, len(methods))
+	for name, method := range methods {
+		w, unique, err := method(r)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		v, newUnique, err := method(cloned)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+
+		effect := CloneEffect{Winner: w, Unique: unique, NewWinner: v, NewUnique: newUnique}
+		effect.Changed = unique != newUnique || unique && v != w && !(w == candidate && v == clone)
+		effects[name] = effect
+	}
+	return effects, nil
+}
+
+// cloned returns the result of the election with a clone of the candidate,
+// ranked right after it on every ballot.
+func (r Result) cloned(candidate int) Result {
+	e := &Election{
+		n:          r.e.n + 1,
+		width:      r.e.width,
+		exact:      r.e.p != nil && r.e.num() < MaxExactCandidates,
+		voters:     r.e.voters,
+		ballots:    r.e.ballots,
+		hook:       r.e.hook,
+		exclusions: r.e.exclusions,
+	}
+	e.init()
+	clone := r.e.num()
+
+	if e.exact {
+		for code, count := range r.e.p {
+			if count == 0 {
+				continue
+			}
+			ballot, _ := DecodeBallot(uint64(code), r.e.num())
+			p := positions(ballot)[candidate] + 1
+			ballot = append(ballot[:p], append([]int{clone}, ballot[p:]...)...)
+			e.p[encode(ballot)] += count
+		}
+		e.dirty = true
+		e.sync()
+		return Result{e}
+	}
+
+	for i := 0; i < e.num(); i++ {
+		for j := 0; j < e.num(); j++ {
+			switch {
+			case i == j:
+			case i == candidate && j == clone:
+				e.m.set(i, j, r.e.voters)
+			case i == clone && j == candidate:
+				e.m.set(i, j, 0)
+			default:
+				e.m.set(i, j, r.e.m.at(original(i, candidate, clone), original(j, candidate, clone)))
+			}
+		}
+	}
+	return Result{e}
+}
+
+// original returns the candidate i, or the original of the clone.
+func original(i, candidate, clone int) int {
+	if i == clone {
+		return candidate
+	}
+	return i
+}
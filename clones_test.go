@@ -0,0 +1,54 @@
+package condorcet_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_CloneEffects checks that cloning a candidate changes the Borda count of Black's method only.
+func TestResult_CloneEffects(t *testing.T) {
+	ballots := [][]int{{2, 3, 1, 0}, {2, 1, 0, 3}, {0, 3, 2, 1}, {0, 2, 3, 1}, {3, 1, 2, 0}, {0, 3, 1, 2}, {1, 0, 2, 3}}
+	exact, _ := condorcet.New(4, condorcet.Exact())
+	e, _ := condorcet.New(4)
+	for _, ballot := range ballots {
+		exact.Vote(ballot...)
+		e.Vote(ballot...)
+	}
+
+	effects, err := exact.Result().CloneEffects(1, condorcet.Methods(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (condorcet.CloneEffect{Winner: 0, Unique: true, NewWinner: 1, NewUnique: true, Changed: true}); effects["Black"] != want {
+		t.Errorf("effect on Black's method is %+v instead of %+v", effects["Black"], want)
+	}
+	for _, name := range []string{"Schulze", "River", "SmithIRV", "Tideman"} {
+		if effect := effects[name]; effect.Changed || effect.NewWinner != 0 {
+			t.Errorf("effect on %s is %+v", name, effect)
+		}
+	}
+
+	// the pairwise matrix is enough for the methods not requiring the ballots
+	pairwise, err := e.Result().CloneEffects(1, condorcet.Methods(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, effect := range pairwise {
+		if !reflect.DeepEqual(effect, effects[name]) {
+			t.Errorf("effect on %s is %+v instead of %+v", name, effect, effects[name])
+		}
+	}
+	if _, err := e.Result().CloneEffects(1, condorcet.Methods(true)); err == nil {
+		t.Error("methods requiring the ballots ran without profile")
+	}
+
+	if _, err := e.Result().CloneEffects(4, condorcet.Methods(false)); err == nil {
+		t.Error("unknown candidate cloned")
+	}
+	truncated, _ := condorcet.New(4, condorcet.AllowTruncation())
+	if _, err := truncated.Result().CloneEffects(1, condorcet.Methods(false)); err == nil {
+		t.Error("candidate of truncated ballots cloned")
+	}
+}
@@ -0,0 +1,55 @@
+// Command condorcet-load runs a load test against an in-memory election
+// and prints the report.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"time"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/loadtest"
+)
+
+func main() {
+	var cfg loadtest.Config
+	flag.IntVar(&cfg.Candidates, "candidates", 10, "number of candidates")
+	flag.IntVar(&cfg.Workers, "workers", 8, "number of concurrent workers")
+	flag.IntVar(&cfg.Rate, "rate", 0, "target operations per second (0 for no limit)")
+	flag.DurationVar(&cfg.Duration, "duration", 10*time.Second, "duration of the test")
+	flag.IntVar(&cfg.ResultEvery, "result-every", 1000, "request a result every n votes per worker (0 for never)")
+	flag.Int64Var(&cfg.Seed, "seed", 1, "seed of the random ballots")
+	exact := flag.Bool("exact", false, "store the profile of the election")
+	sparse := flag.Bool("sparse", false, "use the sparse storage of the tally")
+	journal := flag.Bool("journal", false, "journal the ballots, to a discarding writer")
+	width := flag.Int("tally-width", 0, "width in bits of the tally counters (0 for the default)")
+	flag.Parse()
+
+	if *exact {
+		cfg.Options = append(cfg.Options, condorcet.Exact())
+	}
+	if *sparse {
+		cfg.Options = append(cfg.Options, condorcet.Sparse())
+	}
+	if *journal {
+		cfg.Options = append(cfg.Options, condorcet.Journal(ioutil.Discard))
+	}
+	if *width != 0 {
+		cfg.Options = append(cfg.Options, condorcet.TallyWidth(*width))
+	}
+
+	rep, err := loadtest.Run(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("votes:       %d (%d rejected)\n", rep.Votes, rep.Rejected)
+	fmt.Printf("results:     %d\n", rep.Results)
+	fmt.Printf("elapsed:     %v\n", rep.Elapsed)
+	fmt.Printf("throughput:  %.0f ops/s\n", rep.Throughput)
+	fmt.Printf("vote:        p50=%v p90=%v p99=%v max=%v\n", rep.Vote.P50, rep.Vote.P90, rep.Vote.P99, rep.Vote.Max)
+	fmt.Printf("result:      p50=%v p90=%v p99=%v max=%v\n", rep.Result.P50, rep.Result.P90, rep.Result.P99, rep.Result.Max)
+	fmt.Printf("allocations: %d allocs/op, %d B/op\n", rep.Allocs, rep.AllocBytes)
+}
@@ -0,0 +1,75 @@
+// Package collect is the ballot collection layer of the layered API (see package tally):
+// it enforces one ballot per voter and optionally stores the ballots.
+// Ballots are validated by the tally they are collected into.
+package collect
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/batiazinga/condorcet/tally"
+)
+
+// Errors returned by Box.Cast.
+var (
+	ErrInvalidBallot = errors.New("ballot is rejected by the tally")
+	ErrAlreadyVoted  = errors.New("voter already voted")
+)
+
+// Box collects ballots into a tally.
+type Box struct {
+	t      *tally.Tally
+	voted  map[string]bool
+	stored map[string][][]int // nil if ballots are not stored
+}
+
+// NewBox returns a box collecting ballots into t.
+// If store is true, ballots are kept by voter.
+func NewBox(t *tally.Tally, store bool) *Box {
+	b := &Box{t: t, voted: make(map[string]bool)}
+	if store {
+		b.stored = make(map[string][][]int)
+	}
+	return b
+}
+
+// Cast adds the ballot of the voter to the tally.
+// The ballot is an order over the candidates, from the preferred one.
+func (b *Box) Cast(voter string, ballot ...int) error {
+	groups := make([][]int, len(ballot))
+	for k := range ballot {
+		groups[k] = ballot[k : k+1]
+	}
+	return b.CastRanked(voter, groups...)
+}
+
+// CastRanked adds the ballot with ties of the voter to the tally,
+// as groups of tied candidates from the preferred one (see tally.Tally.Add).
+func (b *Box) CastRanked(voter string, groups ...[]int) error {
+	if b.voted[voter] {
+		return fmt.Errorf("%s: %w", voter, ErrAlreadyVoted)
+	}
+	if !b.t.Add(1, groups...) {
+		return ErrInvalidBallot
+	}
+
+	b.voted[voter] = true
+	if b.stored != nil {
+		stored := make([][]int, len(groups))
+		for k, g := range groups {
+			stored[k] = append([]int(nil), g...)
+		}
+		b.stored[voter] = stored
+	}
+	return nil
+}
+
+// Ballot returns the stored ballot of the voter, as groups of tied candidates.
+// It returns false if the voter did not vote or ballots are not stored.
+func (b *Box) Ballot(voter string) ([][]int, bool) {
+	ballot, ok := b.stored[voter]
+	return ballot, ok
+}
+
+// Tally returns the tally fed by the box.
+func (b *Box) Tally() *tally.Tally { return b.t }
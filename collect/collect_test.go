@@ -0,0 +1,53 @@
+package collect_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet/collect"
+	"github.com/batiazinga/condorcet/tally"
+)
+
+// TestBox_Cast checks that each voter casts a single valid ballot.
+func TestBox_Cast(t *testing.T) {
+	tl, _ := tally.New(3)
+	box := collect.NewBox(tl, true)
+
+	if err := box.Cast("alice", 2, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := box.CastRanked("bob", []int{0, 1}, []int{2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := box.Cast("alice", 0, 1, 2); !errors.Is(err, collect.ErrAlreadyVoted) {
+		t.Errorf("unexpected error for a second ballot: %v", err)
+	}
+	if err := box.Cast("carol", 0, 1); err != collect.ErrInvalidBallot {
+		t.Errorf("unexpected error for an invalid ballot: %v", err)
+	}
+	// a rejected ballot does not count as a vote
+	if err := box.Cast("carol", 0, 1, 2); err != nil {
+		t.Errorf("valid ballot rejected after an invalid one: %v", err)
+	}
+
+	if n := box.Tally().NumVoters(); n != 3 {
+		t.Errorf("%d voters instead of 3", n)
+	}
+	if ballot, ok := box.Ballot("bob"); !ok || !reflect.DeepEqual(ballot, [][]int{{0, 1}, {2}}) {
+		t.Errorf("unexpected stored ballot (%v, %v)", ballot, ok)
+	}
+	if _, ok := box.Ballot("dave"); ok {
+		t.Error("ballot of a voter who did not vote")
+	}
+}
+
+// TestBox_noStorage checks that ballots are not kept unless asked.
+func TestBox_noStorage(t *testing.T) {
+	tl, _ := tally.New(2)
+	box := collect.NewBox(tl, false)
+	box.Cast("alice", 1, 0)
+	if _, ok := box.Ballot("alice"); ok {
+		t.Error("ballot stored")
+	}
+}
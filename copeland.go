@@ -0,0 +1,73 @@
+package condorcet
+
+// Copeland returns the outcome of the Copeland method:
+// the score of a candidate is the number of pairwise contests it wins
+// minus the number of contests it loses, and the highest score wins.
+//
+// It returns the winner, false if several candidates tie for the highest score,
+// and the scores indexed by candidate.
+// Candidates excluded by the eligibility hook have a zero score and cannot win.
+// If "none of the above" has the best score, even tied, there is no valid winner (see NoneOfTheAbove).
+func (r Result) Copeland() (w int, unique bool, scores []int) {
+	r.e.do(PhaseCopeland, func() { scores = r.copeland() })
+
+	rk := rankByScore(r.e.candidates(), scores)
+	if len(rk) == 0 {
+		return 0, false, scores
+	}
+	w, unique = r.elect(rk[0])
+	return w, unique, scores
+}
+
+// copeland returns the Copeland scores.
+func (r Result) copeland() []int {
+	scores := make([]int, r.e.num())
+	for c, s := range r.Scores() {
+		scores[c] = s.Wins - s.Losses
+	}
+	return scores
+}
+
+// Score is the record of a candidate in its pairwise contests.
+type Score struct {
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+	Ties   int `json:"ties"`
+}
+
+// Scores returns the number of pairwise contests each candidate wins, loses and ties,
+// indexed by candidate.
+// Candidates excluded by the eligibility hook have a zero score
+// and are not counted in the scores of the others.
+func (r Result) Scores() []Score {
+	candidates := r.e.candidates()
+	scores := make([]Score, r.e.num())
+	for _, i := range candidates {
+		for _, j := range candidates {
+			switch {
+			case i == j:
+			case r.e.m.at(i, j) > r.e.m.at(j, i):
+				scores[i].Wins++
+			case r.e.m.at(i, j) < r.e.m.at(j, i):
+				scores[i].Losses++
+			default:
+				scores[i].Ties++
+			}
+		}
+	}
+	return scores
+}
+
+func init() {
+	completions = append(completions, completion{
+		name: "Copeland",
+		winners: func(r Result) []int {
+			_, _, scores := r.Copeland()
+			rk := rankByScore(r.e.candidates(), scores)
+			if len(rk) == 0 {
+				return nil
+			}
+			return rk[0]
+		},
+	})
+}
@@ -0,0 +1,46 @@
+package condorcet_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_Copeland checks the Copeland method on the testcases.
+func TestResult_Copeland(t *testing.T) {
+	testcases := []struct {
+		label  string
+		winner int
+		unique bool
+		scores []int
+	}{
+		{"Condorcet's example", 2, true, []int{-2, 0, 2}},
+		{"4 candidates", 3, true, []int{1, -1, -3, 3}},
+		{"paradoxe", 0, false, []int{0, 0, 0}},
+		{"no vote", 0, false, []int{0, 0, 0, 0, 0, 0}},
+	}
+
+	for _, tc := range testcases {
+		w, unique, scores := result(t, tc.label).Copeland()
+		if w != tc.winner || unique != tc.unique {
+			t.Errorf("%s: winner (%d, %v) instead of (%d, %v)", tc.label, w, unique, tc.winner, tc.unique)
+		}
+		if !reflect.DeepEqual(scores, tc.scores) {
+			t.Errorf("%s: scores %v instead of %v", tc.label, scores, tc.scores)
+		}
+	}
+}
+
+// TestResult_Scores checks the pairwise records of the candidates.
+func TestResult_Scores(t *testing.T) {
+	want := []condorcet.Score{{Wins: 0, Losses: 2}, {Wins: 1, Losses: 1}, {Wins: 2}}
+	if s := result(t, "Condorcet's example").Scores(); !reflect.DeepEqual(s, want) {
+		t.Errorf("scores are %+v instead of %+v", s, want)
+	}
+
+	s := result(t, "no vote").Scores()
+	if len(s) != 6 || s[0] != (condorcet.Score{Ties: 5}) {
+		t.Errorf("unexpected scores without vote: %+v", s)
+	}
+}
@@ -0,0 +1,137 @@
+package condorcet
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// Cast vote records (CVR) are exported one ballot per record,
+// in the layouts consumed by common risk-limiting audit tools.
+// The profile does not keep the order of arrival,
+// so records are numbered from 1 in the order of the Lehmer codes of the ballots.
+
+// WriteCVRCSV writes the cast vote records as CSV.
+// The header is "CvrId" followed by one column per candidate,
+// named after the candidate (see NewNamed) or "Candidate i" if the election is not named;
+// each record gives the rank of each candidate, from 1 for the preferred one.
+//
+// It requires the full profile of the election (see Exact).
+func (r Result) WriteCVRCSV(w io.Writer) error {
+	if r.e.p == nil {
+		return ErrNoProfile
+	}
+
+	cw := csv.NewWriter(w)
+	record := make([]string, r.e.num()+1)
+	record[0] = "CvrId"
+	for c := 0; c < r.e.num(); c++ {
+		if r.e.names != nil {
+			record[c+1] = r.Name(c)
+		} else {
+			record[c+1] = "Candidate " + strconv.Itoa(c)
+		}
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+
+	err := r.eachCVR(func(id int, ballot []int) error {
+		record[0] = strconv.Itoa(id)
+		for rank, c := range ballot {
+			record[c+1] = strconv.Itoa(rank + 1)
+		}
+		return cw.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// cvrJSON is a cast vote record in JSON.
+type cvrJSON struct {
+	ID      int   `json:"id"`
+	Ranking []int `json:"ranking"` // candidates from the preferred one
+}
+
+// WriteCVRJSON writes the cast vote records and the tally as a JSON document:
+//
+//	{"candidates": n, "voters": v, "pairwise": [[...]], "cvrs": [{"id": 1, "ranking": [...]}, ...]}
+//
+// where pairwise[i][j] is the number of voters preferring i to j.
+// If the candidates are named (see NewNamed), their names follow the number of candidates: "names": [...].
+// If the candidates are described (see Describe), their metadata follows the number of candidates:
+// "metadata": [{"display_name": ..., "party": ..., "url": ..., "extra": {...}}, ...].
+//
+// It requires the full profile of the election (see Exact).
+func (r Result) WriteCVRJSON(w io.Writer) error {
+	if r.e.p == nil {
+		return ErrNoProfile
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+
+	bw.WriteString(`{"candidates":` + strconv.Itoa(r.e.num()))
+	if r.e.names != nil {
+		names := make([]string, r.e.num())
+		for c := range names {
+			names[c] = r.Name(c)
+		}
+		bw.WriteString(`,"names":`)
+		if err := enc.Encode(names); err != nil {
+			return err
+		}
+	}
+	if r.e.described() {
+		bw.WriteString(`,"metadata":`)
+		if err := enc.Encode(r.e.meta); err != nil {
+			return err
+		}
+	}
+	bw.WriteString(`,"voters":` + strconv.Itoa(r.NumVoters()))
+	bw.WriteString(`,"pairwise":[`)
+	for i := 0; i < r.e.num(); i++ {
+		if i > 0 {
+			bw.WriteString(",")
+		}
+		if err := enc.Encode(r.row(i)); err != nil {
+			return err
+		}
+	}
+	bw.WriteString(`],"cvrs":[`)
+
+	err := r.eachCVR(func(id int, ballot []int) error {
+		if id > 1 {
+			bw.WriteString(",")
+		}
+		return enc.Encode(cvrJSON{id, ballot})
+	})
+	if err != nil {
+		return err
+	}
+	bw.WriteString("]}\n")
+	return bw.Flush()
+}
+
+// eachCVR calls f for each ballot of the profile, with its record identifier.
+func (r Result) eachCVR(f func(id int, ballot []int) error) error {
+	id := 1
+	for code, count := range r.e.p {
+		if count == 0 {
+			continue
+		}
+		ballot, _ := DecodeBallot(uint64(code), r.e.num())
+		for k := 0; k < count; k++ {
+			if err := f(id, ballot); err != nil {
+				return err
+			}
+			id++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package condorcet_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_WriteCVR checks the CSV and JSON cast vote records.
+func TestResult_WriteCVR(t *testing.T) {
+	e, _ := condorcet.New(3, condorcet.Exact())
+	e.Vote(2, 0, 1)
+	e.Vote(2, 0, 1)
+	e.Vote(0, 1, 2)
+	r := e.Result()
+
+	var buf bytes.Buffer
+	if err := r.WriteCVRCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "CvrId,Candidate 0,Candidate 1,Candidate 2\n1,1,2,3\n2,2,3,1\n3,2,3,1\n"
+	if buf.String() != want {
+		t.Errorf("unexpected CSV:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := r.WriteCVRJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Candidates int
+		Voters     int
+		Pairwise   [][]int
+		CVRs       []struct {
+			ID      int
+			Ranking []int
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if doc.Candidates != 3 || doc.Voters != 3 || len(doc.CVRs) != 3 || doc.Pairwise[2][0] != 2 {
+		t.Errorf("unexpected document: %+v", doc)
+	}
+
+	e, _ = condorcet.New(3)
+	if err := e.Result().WriteCVRCSV(&buf); err != condorcet.ErrNoProfile {
+		t.Errorf("unexpected error without profile: %v", err)
+	}
+}
+
+// TestResult_WriteCVRNamed checks that the cast vote records of a named election use the names.
+func TestResult_WriteCVRNamed(t *testing.T) {
+	e, _ := condorcet.NewNamed([]string{"Alice", "Bob"}, condorcet.Exact())
+	e.Vote(1, 0)
+	r := e.Result()
+
+	var buf bytes.Buffer
+	if err := r.WriteCVRCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if want := "CvrId,Alice,Bob\n1,2,1\n"; buf.String() != want {
+		t.Errorf("unexpected CSV:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := r.WriteCVRJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct{ Names []string }
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(doc.Names) != 2 || doc.Names[0] != "Alice" || doc.Names[1] != "Bob" {
+		t.Errorf("unexpected names %v", doc.Names)
+	}
+}
@@ -0,0 +1,338 @@
+package condorcet
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Defeat is a pairwise defeat: Winner is preferred to Loser by Margin more voters.
+type Defeat struct {
+	Winner int `json:"winner"`
+	Loser  int `json:"loser"`
+	Margin int `json:"margin"`
+}
+
+// beatsOrTies reports whether i beats or ties j.
+func (r Result) beatsOrTies(i, j int) bool {
+	return r.e.m.at(i, j) >= r.e.m.at(j, i)
+}
+
+// Smith returns the Smith set, in increasing order:
+// the smallest non-empty set of candidates who beat every candidate outside the set.
+// It is the winner alone if there is a Condorcet winner.
+//
+// Candidates excluded by the eligibility hook are ignored.
+func (r Result) Smith() []int { return r.smith(r.e.candidates()) }
+
+// smith returns the Smith set of the given candidates, in increasing order.
+func (r Result) smith(candidates []int) []int {
+	n := len(candidates)
+
+	// reach[a][b]: candidates[a] beats or ties candidates[b], directly or through a chain
+	reach := make([][]bool, n)
+	for a := range reach {
+		reach[a] = make([]bool, n)
+		for b := range reach[a] {
+			reach[a][b] = a == b || r.beatsOrTies(candidates[a], candidates[b])
+		}
+	}
+	for k := 0; k < n; k++ {
+		for a := 0; a < n; a++ {
+			for b := 0; b < n; b++ {
+				reach[a][b] = reach[a][b] || (reach[a][k] && reach[k][b])
+			}
+		}
+	}
+
+	var smith []int
+	for a := 0; a < n; a++ {
+		top := true
+		for b := 0; b < n; b++ {
+			if !reach[a][b] {
+				top = false
+				break
+			}
+		}
+		if top {
+			smith = append(smith, candidates[a])
+		}
+	}
+	sort.Ints(smith)
+	return smith
+}
+
+// Uncovered returns the uncovered set, also known as the Landau set, in increasing order.
+// Candidate a covers candidate b if a beats b and every candidate beaten by b.
+// The uncovered set is made of the candidates covered by no other candidate:
+// it is included in the Smith set.
+//
+// Candidates excluded by the eligibility hook are ignored.
+func (r Result) Uncovered() []int {
+	candidates := r.e.candidates()
+	beats := func(a, b int) bool { return r.e.m.at(a, b) > r.e.m.at(b, a) }
+	covers := func(a, b int) bool {
+		if !beats(a, b) {
+			return false
+		}
+		for _, c := range candidates {
+			if c != a && beats(b, c) && !beats(a, c) {
+				return false
+			}
+		}
+		return true
+	}
+
+	var uncovered []int
+	for _, b := range candidates {
+		covered := false
+		for _, a := range candidates {
+			if a != b && covers(a, b) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			uncovered = append(uncovered, b)
+		}
+	}
+	return uncovered
+}
+
+// Cycles returns the minimal majority cycles among the potential winners, i.e. the Smith set:
+// each cycle is a sequence of candidates where each candidate beats the next one
+// and the last one beats the first one, and no subset of its candidates forms a cycle.
+// Cycles start with their smallest candidate and are sorted by length, then candidates.
+//
+// There is no cycle if there is a Condorcet winner, or if the top candidates only tie.
+// Candidates excluded by the eligibility hook are ignored.
+//
+// If no two candidates of the Smith set tie, every cycle contains a cycle of 3 candidates,
+// so the minimal cycles are found in O(n^3) time for n candidates.
+// Otherwise every elementary cycle is enumerated, which takes exponential time in the worst case
+// (see CyclesCtx).
+func (r Result) Cycles() [][]int {
+	cycles, _ := r.CyclesCtx(context.Background())
+	return cycles
+}
+
+// CyclesCtx is like Cycles, but abandons the search when ctx is done,
+// e.g. to impose a timeout, and then returns the error of ctx.
+func (r Result) CyclesCtx(ctx context.Context) ([][]int, error) {
+	smith := r.Smith()
+	var cycles [][]int
+	if r.decisive(smith) {
+		cycles = r.triangles(smith)
+	} else {
+		cycles = r.minimalCycles(smith, stopper(ctx))
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(cycles, func(i, j int) bool {
+		a, b := cycles[i], cycles[j]
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		for k := range a {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return false
+	})
+	return cycles, nil
+}
+
+// beats reports whether a beats b in their pairwise contest.
+func (r Result) beats(a, b int) bool { return r.e.m.at(a, b) > r.e.m.at(b, a) }
+
+// decisive reports whether no two of the candidates tie in their pairwise contest.
+func (r Result) decisive(candidates []int) bool {
+	for k, a := range candidates {
+		for _, b := range candidates[k+1:] {
+			if r.e.m.at(a, b) == r.e.m.at(b, a) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// triangles returns the cycles of 3 of the candidates, in increasing order, from their smallest candidate.
+func (r Result) triangles(candidates []int) [][]int {
+	var cycles [][]int
+	for i, a := range candidates {
+		for j, b := range candidates[i+1:] {
+			for _, c := range candidates[i+j+2:] {
+				switch {
+				case r.beats(a, b) && r.beats(b, c) && r.beats(c, a):
+					cycles = append(cycles, []int{a, b, c})
+				case r.beats(a, c) && r.beats(c, b) && r.beats(b, a):
+					cycles = append(cycles, []int{a, c, b})
+				}
+			}
+		}
+	}
+	return cycles
+}
+
+// minimalCycles enumerates the elementary cycles among the candidates
+// and returns the minimal ones, or nil as soon as stop, if not nil, returns true.
+func (r Result) minimalCycles(smith []int, stop func() bool) [][]int {
+	stopped := false
+
+	// elementary cycles, from their smallest candidate
+	var cycles [][]int
+	path := make([]int, 0, len(smith))
+	onPath := make(map[int]bool, len(smith))
+	var visit func(start, c int)
+	visit = func(start, c int) {
+		if stopped = stopped || (stop != nil && stop()); stopped {
+			return
+		}
+		path = append(path, c)
+		onPath[c] = true
+		for _, next := range smith {
+			if next < start || !r.beats(c, next) {
+				continue
+			}
+			if next == start {
+				cycles = append(cycles, append([]int(nil), path...))
+			} else if !onPath[next] {
+				visit(start, next)
+			}
+		}
+		path = path[:len(path)-1]
+		onPath[c] = false
+	}
+	for _, start := range smith {
+		visit(start, start)
+	}
+	if stopped {
+		return nil
+	}
+
+	// minimal cycles
+	subset := func(a, b []int) bool {
+		for _, c := range a {
+			found := false
+			for _, d := range b {
+				found = found || c == d
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+	var minimal [][]int
+	for _, c := range cycles {
+		keep := true
+		for _, o := range cycles {
+			if len(o) < len(c) && subset(o, c) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			minimal = append(minimal, c)
+		}
+	}
+	return minimal
+}
+
+// defeats returns the strict pairwise defeats among the candidates,
+// by decreasing margin, then increasing winner and loser.
+func (r Result) defeats(candidates []int) []Defeat {
+	var defeats []Defeat
+	for _, a := range candidates {
+		for _, b := range candidates {
+			if margin := r.e.m.at(a, b) - r.e.m.at(b, a); margin > 0 {
+				defeats = append(defeats, Defeat{a, b, margin})
+			}
+		}
+	}
+	sort.SliceStable(defeats, func(i, j int) bool {
+		if defeats[i].Margin != defeats[j].Margin {
+			return defeats[i].Margin > defeats[j].Margin
+		}
+		if defeats[i].Winner != defeats[j].Winner {
+			return defeats[i].Winner < defeats[j].Winner
+		}
+		return defeats[i].Loser < defeats[j].Loser
+	})
+	return defeats
+}
+
+// CycleBreak is the way a completion method breaks the top cycle.
+type CycleBreak struct {
+	Method    string   `json:"method"`
+	Winners   []int    `json:"winners"`   // winners of the method, several if tied
+	Discarded []Defeat `json:"discarded"` // defeats of the top cycle the method overrides
+}
+
+// CycleReport explains a majority cycle to committees choosing a completion method.
+type CycleReport struct {
+	Smith   []int        `json:"smith"`   // the top cycle
+	Defeats []Defeat     `json:"defeats"` // defeats within the top cycle, strongest first
+	Breaks  []CycleBreak `json:"breaks"`  // one per completion method
+}
+
+// CycleReport returns the report of the cycles at the top of the majority relation.
+// If there is a Condorcet winner, there is no cycle to report and it returns false.
+func (r Result) CycleReport() (CycleReport, bool) {
+	smith := r.Smith()
+	if len(smith) < 2 {
+		return CycleReport{}, false
+	}
+
+	rep := CycleReport{
+		Smith:   smith,
+		Defeats: r.defeats(smith),
+	}
+	for _, m := range completions {
+		winners := m.winners(r)
+		res := CycleBreak{Method: m.name, Winners: winners}
+		// a defeat of a winner within the top cycle is overridden
+		for _, d := range rep.Defeats {
+			for _, w := range winners {
+				if d.Loser == w {
+					res.Discarded = append(res.Discarded, d)
+					break
+				}
+			}
+		}
+		rep.Breaks = append(rep.Breaks, res)
+	}
+	return rep, true
+}
+
+// completion is a completion method taking part in the cycle report.
+type completion struct {
+	name    string
+	winners func(r Result) []int
+}
+
+// completions are the completion methods compared by the cycle report.
+var completions []completion
+
+// WriteText writes the report as plain text.
+func (rep CycleReport) WriteText(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "top cycle: %v\n", rep.Smith)
+	fmt.Fprintln(bw, "defeats:")
+	for _, d := range rep.Defeats {
+		fmt.Fprintf(bw, "  %d beats %d by %d\n", d.Winner, d.Loser, d.Margin)
+	}
+	for _, res := range rep.Breaks {
+		fmt.Fprintf(bw, "%s: winners %v\n", res.Method, res.Winners)
+		for _, d := range res.Discarded {
+			fmt.Fprintf(bw, "  discards %d beats %d by %d\n", d.Winner, d.Loser, d.Margin)
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,110 @@
+package condorcet_test
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_Smith checks the Smith set of the testcases.
+func TestResult_Smith(t *testing.T) {
+	if s := result(t, "Condorcet's example").Smith(); !reflect.DeepEqual(s, []int{2}) {
+		t.Errorf("unexpected Smith set %v with a Condorcet winner", s)
+	}
+	if s := result(t, "paradoxe").Smith(); !reflect.DeepEqual(s, []int{0, 1, 2}) {
+		t.Errorf("unexpected Smith set %v in the paradox", s)
+	}
+}
+
+// TestResult_Uncovered checks the uncovered set of the testcases.
+func TestResult_Uncovered(t *testing.T) {
+	testcases := []struct {
+		label     string
+		uncovered []int
+	}{
+		{"Condorcet's example", []int{2}},
+		{"paradoxe", []int{0, 1, 2}},
+		{"Schulze's example", []int{0, 2, 4}}, // E covers B and A covers D
+		{"no vote", []int{0, 1, 2, 3, 4, 5}},
+	}
+	for _, tc := range testcases {
+		if u := result(t, tc.label).Uncovered(); !reflect.DeepEqual(u, tc.uncovered) {
+			t.Errorf("%s: uncovered set %v instead of %v", tc.label, u, tc.uncovered)
+		}
+	}
+}
+
+// TestResult_Cycles checks the minimal cycles of the testcases.
+func TestResult_Cycles(t *testing.T) {
+	testcases := []struct {
+		label  string
+		cycles [][]int
+	}{
+		{"Condorcet's example", nil},
+		{"paradoxe", [][]int{{0, 1, 2}}},
+		{"no vote", nil},
+		// A beats C and D, B beats A and D, C beats B and E, D beats C, E beats A, B and D
+		{"Schulze's example", [][]int{{0, 2, 1}, {0, 2, 4}, {1, 3, 2}, {2, 4, 3}}},
+	}
+	for _, tc := range testcases {
+		if c := result(t, tc.label).Cycles(); !reflect.DeepEqual(c, tc.cycles) {
+			t.Errorf("%s: cycles %v instead of %v", tc.label, c, tc.cycles)
+		}
+	}
+}
+
+// TestResult_CycleReport checks the report of the paradox.
+func TestResult_CycleReport(t *testing.T) {
+	if _, ok := result(t, "Condorcet's example").CycleReport(); ok {
+		t.Error("cycle reported with a Condorcet winner")
+	}
+
+	rep, ok := result(t, "paradoxe").CycleReport()
+	if !ok {
+		t.Fatal("no cycle reported in the paradox")
+	}
+	if len(rep.Defeats) != 3 {
+		t.Errorf("unexpected defeats %v", rep.Defeats)
+	}
+
+	var buf bytes.Buffer
+	if err := rep.WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestResult_CyclesCtx checks the minimal cycles when candidates tie, and the cancellation of the search.
+func TestResult_CyclesCtx(t *testing.T) {
+	// 0 beats 1, 1 beats 2, 2 beats 3 and 3 beats 0, while 0 and 2, and 1 and 3 tie
+	e, _ := condorcet.New(4)
+	e.Vote(0, 1, 2, 3)
+	e.Vote(1, 2, 3, 0)
+	e.Vote(2, 3, 0, 1)
+	e.Vote(3, 0, 1, 2)
+	r := e.Result()
+	if c, err := r.CyclesCtx(context.Background()); err != nil || !reflect.DeepEqual(c, [][]int{{0, 1, 2, 3}}) {
+		t.Errorf("cycles %v: %v", c, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := r.CyclesCtx(ctx); err != context.Canceled {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	// the 3-cycles of a large tournament
+	rnd := rand.New(rand.NewSource(1))
+	e, _ = condorcet.New(40)
+	for k := 0; k < 41; k++ {
+		e.Vote(rnd.Perm(40)...)
+	}
+	for _, c := range e.Result().Cycles() {
+		if len(c) != 3 {
+			t.Fatalf("cycle %v in a tournament", c)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Package dashboard serves a live HTML dashboard of election results:
+// turnout, the pairwise table, the current winner and the winners of the completion methods.
+//
+// Like the feed package, it only exposes aggregated figures, never the ballots.
+// The page refreshes itself with conditional requests,
+// which are answered with 304 Not Modified while the result is unchanged.
+package dashboard
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"html/template"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// Refresh is the interval between two refreshes of the page.
+const Refresh = 5 * time.Second
+
+// Page is the content of the dashboard.
+type Page struct {
+	Candidates []string
+	Voters     int
+	Pairwise   [][]int // Pairwise[i][j] is the number of voters preferring i to j
+	Winner     string  // empty if there is no winner
+	Methods    []Method
+}
+
+// Method is the outcome of a completion method (see condorcet.Methods).
+type Method struct {
+	Name   string
+	Winner string // empty if the candidates tie or if the method is not available
+	Err    string // why the method is not available, e.g. without the ballots
+}
+
+// NewPage returns the content of the dashboard of the result.
+func NewPage(r condorcet.Result) Page {
+	n := r.NumCandidates()
+	p := Page{
+		Candidates: make([]string, n),
+		Voters:     r.NumVoters(),
+		Pairwise:   make([][]int, n),
+	}
+	for i := range p.Pairwise {
+		p.Candidates[i] = r.Name(i)
+		p.Pairwise[i] = make([]int, n)
+		for j := range p.Pairwise[i] {
+			p.Pairwise[i][j] = r.Pairwise(i, j)
+		}
+	}
+	if w, exist := r.WinnerName(); exist {
+		p.Winner = w
+	}
+
+	methods := condorcet.Methods(true)
+	names := make([]string, 0, len(methods))
+	for name := range methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		m := Method{Name: name}
+		w, unique, err := methods[name](r)
+		switch {
+		case err != nil:
+			m.Err = err.Error()
+		case unique && p.Voters > 0:
+			m.Winner = r.Name(w)
+		}
+		p.Methods = append(p.Methods, m)
+	}
+	return p
+}
+
+// Handler returns a handler serving the dashboard of the result returned by source.
+// source is called on each request.
+//
+// Only GET and HEAD requests are allowed.
+func Handler(source func() condorcet.Result) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := page.Execute(&buf, NewPage(source())); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		sum := sha256.Sum256(buf.Bytes())
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+		h := w.Header()
+		h.Set("ETag", etag)
+		h.Set("Cache-Control", "no-cache")
+
+		if req.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		h.Set("Content-Type", "text/html; charset=utf-8")
+		if req.Method == http.MethodHead {
+			return
+		}
+		w.Write(buf.Bytes())
+	})
+}
+
+// page is the template of the dashboard.
+// Its script fetches the page again after each interval and replaces the content if it changed.
+var page = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"refresh": func() int64 { return int64(Refresh / time.Millisecond) },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Election results</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: right; }
+</style>
+</head>
+<body>
+<main id="dashboard">
+<h1>Election results</h1>
+<p>Turnout: <strong>{{.Voters}}</strong> voter{{if ne .Voters 1}}s{{end}}</p>
+<p>{{if .Winner}}Condorcet winner: <strong>{{.Winner}}</strong>{{else}}No Condorcet winner{{end}}</p>
+
+<h2>Pairwise preferences</h2>
+<table>
+<tr><th></th>{{range .Candidates}}<th>{{.}}</th>{{end}}</tr>
+{{range $i, $row := .Pairwise}}<tr><th>{{index $.Candidates $i}}</th>{{range $j, $count := $row}}<td>{{if ne $i $j}}{{$count}}{{end}}</td>{{end}}</tr>
+{{end}}</table>
+
+<h2>Methods</h2>
+<table>
+{{range .Methods}}<tr><th>{{.Name}}</th><td>{{if .Err}}n/a{{else if .Winner}}{{.Winner}}{{else}}tie{{end}}</td></tr>
+{{end}}</table>
+</main>
+<script>
+(function () {
+	var etag = null;
+	function refresh() {
+		var headers = etag ? {"If-None-Match": etag} : {};
+		fetch(location.href, {headers: headers, cache: "no-cache"}).then(function (res) {
+			if (res.status !== 200) {
+				return;
+			}
+			etag = res.headers.get("ETag");
+			return res.text().then(function (html) {
+				var doc = new DOMParser().parseFromString(html, "text/html");
+				document.getElementById("dashboard").replaceWith(doc.getElementById("dashboard"));
+			});
+		}).catch(function () {}).then(function () {
+			setTimeout(refresh, {{refresh}});
+		});
+	}
+	setTimeout(refresh, {{refresh}});
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,75 @@
+package dashboard_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/dashboard"
+)
+
+// TestNewPage checks the content of the dashboard.
+func TestNewPage(t *testing.T) {
+	e, _ := condorcet.NewNamed([]string{"Alice", "Bob", "Carol"})
+	e.Vote(2, 0, 1)
+	e.Vote(2, 1, 0)
+	e.Vote(0, 1, 2)
+
+	p := dashboard.NewPage(e.Result())
+	if p.Voters != 3 || p.Winner != "Carol" {
+		t.Errorf("unexpected turnout and winner (%d, %q)", p.Voters, p.Winner)
+	}
+	if p.Candidates[1] != "Bob" || p.Pairwise[2][0] != 2 || p.Pairwise[0][2] != 1 {
+		t.Errorf("unexpected pairwise table %v of %v", p.Pairwise, p.Candidates)
+	}
+	if len(p.Methods) != len(condorcet.Methods(true)) {
+		t.Fatalf("%d methods instead of %d", len(p.Methods), len(condorcet.Methods(true)))
+	}
+	for _, m := range p.Methods {
+		if m.Err == "" && m.Winner != "Carol" {
+			t.Errorf("%s elects %q", m.Name, m.Winner)
+		}
+	}
+}
+
+// TestHandler asserts that the dashboard is served and honours If-None-Match.
+func TestHandler(t *testing.T) {
+	e, _ := condorcet.NewNamed([]string{"Alice", "<Bob>"})
+	e.Vote(1, 0)
+	h := dashboard.Handler(e.Result)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d", rec.Code)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "<strong>&lt;Bob&gt;</strong>") {
+		t.Errorf("winner missing or not escaped:\n%s", body)
+	}
+
+	// same result: not modified
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("unexpected status %d for a fresh ETag", rec.Code)
+	}
+
+	// new vote: modified
+	e.Vote(0, 1)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("unexpected status %d for a stale ETag", rec.Code)
+	}
+
+	// read-only
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("unexpected status %d for POST", rec.Code)
+	}
+}
@@ -0,0 +1,131 @@
+// Package dedup prevents voter tokens from being used twice
+// in elections with millions of voters.
+//
+// A Bloom filter answers most lookups from a compact bit array.
+// Only when the filter reports a possible duplicate is the exact Store consulted,
+// so the store can live outside the process (a database for instance)
+// and does not need to be held in memory.
+package dedup
+
+import (
+	"errors"
+	"hash/fnv"
+	"math"
+	"sync"
+)
+
+// Store is an exact set of voter tokens.
+type Store interface {
+	Contains(token string) (bool, error)
+	Add(token string) error
+}
+
+// MapStore is an in-memory Store.
+type MapStore map[string]struct{}
+
+// Contains implements Store.
+func (s MapStore) Contains(token string) (bool, error) {
+	_, ok := s[token]
+	return ok, nil
+}
+
+// Add implements Store.
+func (s MapStore) Add(token string) error {
+	s[token] = struct{}{}
+	return nil
+}
+
+// Bloom is a Bloom filter backed by an exact store.
+// It is safe for concurrent use if the store is.
+type Bloom struct {
+	mu    sync.Mutex
+	bits  []uint64
+	m     uint64 // number of bits
+	k     int    // number of hash functions
+	store Store
+}
+
+// NewBloom returns a filter sized for the expected number of tokens
+// with the given false positive rate, in (0, 1).
+//
+// False positives are resolved by the store.
+// If the store is nil, there is no exact fallback:
+// a new token is then wrongly rejected with a probability bounded by the rate,
+// as long as the expected number of tokens is not exceeded.
+func NewBloom(expected int, rate float64, store Store) (*Bloom, error) {
+	if expected < 1 {
+		return nil, errors.New("expecting at least 1 token")
+	}
+	if rate <= 0 || rate >= 1 {
+		return nil, errors.New("false positive rate must be in (0, 1)")
+	}
+
+	// optimal number of bits and hash functions
+	m := math.Ceil(-float64(expected) * math.Log(rate) / (math.Ln2 * math.Ln2))
+	k := int(math.Round(m / float64(expected) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+
+	return &Bloom{
+		bits:  make([]uint64, (uint64(m)+63)/64),
+		m:     uint64(m),
+		k:     k,
+		store: store,
+	}, nil
+}
+
+// positions returns the bits of the token.
+// They are derived from two hashes (Kirsch-Mitzenmacher).
+func (b *Bloom) positions(token string) []uint64 {
+	h1 := fnv.New64a()
+	h1.Write([]byte(token))
+	h2 := fnv.New64()
+	h2.Write([]byte(token))
+	a, c := h1.Sum64(), h2.Sum64()|1
+
+	pos := make([]uint64, b.k)
+	for i := range pos {
+		pos[i] = (a + uint64(i)*c) % b.m
+	}
+	return pos
+}
+
+// Claim records the use of the token.
+// It returns true if the token was not used before.
+func (b *Bloom) Claim(token string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	pos := b.positions(token)
+	maybe := true
+	for _, p := range pos {
+		if b.bits[p/64]&(1<<(p%64)) == 0 {
+			maybe = false
+			break
+		}
+	}
+
+	if maybe {
+		if b.store == nil {
+			return false, nil
+		}
+		seen, err := b.store.Contains(token)
+		if err != nil {
+			return false, err
+		}
+		if seen {
+			return false, nil
+		}
+	}
+
+	if b.store != nil {
+		if err := b.store.Add(token); err != nil {
+			return false, err
+		}
+	}
+	for _, p := range pos {
+		b.bits[p/64] |= 1 << (p % 64)
+	}
+	return true, nil
+}
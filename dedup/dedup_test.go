@@ -0,0 +1,54 @@
+package dedup_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/batiazinga/condorcet/dedup"
+)
+
+// TestBloom_Claim asserts that duplicates are always detected
+// and that the exact store prevents false rejections.
+func TestBloom_Claim(t *testing.T) {
+	const n = 10000
+
+	b, err := dedup.NewBloom(n, 0.01, dedup.MapStore{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		token := strconv.Itoa(i)
+		if ok, _ := b.Claim(token); !ok {
+			t.Fatalf("new token %q rejected", token)
+		}
+		if ok, _ := b.Claim(token); ok {
+			t.Fatalf("duplicate token %q accepted", token)
+		}
+	}
+}
+
+// TestBloom_Claim_noStore checks the false positive rate without exact store.
+func TestBloom_Claim_noStore(t *testing.T) {
+	const n = 10000
+
+	b, _ := dedup.NewBloom(n, 0.01, nil)
+	var rejected int
+	for i := 0; i < n; i++ {
+		if ok, _ := b.Claim(strconv.Itoa(i)); !ok {
+			rejected++
+		}
+	}
+	if rejected > n/50 {
+		t.Errorf("%d new tokens rejected out of %d", rejected, n)
+	}
+}
+
+// TestNewBloom_invalid asserts that invalid parameters are rejected.
+func TestNewBloom_invalid(t *testing.T) {
+	if _, err := dedup.NewBloom(0, 0.01, nil); err == nil {
+		t.Error("filter without expected tokens accepted")
+	}
+	if _, err := dedup.NewBloom(10, 1, nil); err == nil {
+		t.Error("false positive rate of 1 accepted")
+	}
+}
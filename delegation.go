@@ -0,0 +1,238 @@
+package condorcet
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Proxies collects the ballots of voters who may designate a proxy.
+//
+// The ballot of a proxy is counted once for the proxy
+// and once for each principal who did not cast a ballot.
+// A principal's own ballot always overrides the proxy.
+// Designations are not transitive: the proxy must cast a ballot.
+type Proxies struct {
+	proxy   map[string]string // principal to proxy
+	ballots map[string][]int  // voter to ballot
+}
+
+// NewProxies returns an empty collection of ballots and designations.
+func NewProxies() *Proxies {
+	return &Proxies{
+		proxy:   make(map[string]string),
+		ballots: make(map[string][]int),
+	}
+}
+
+// Designate makes proxy vote on behalf of principal.
+// It replaces any previous designation of the principal.
+func (p *Proxies) Designate(principal, proxy string) error {
+	if principal == proxy {
+		return errors.New("a voter cannot be its own proxy")
+	}
+	p.proxy[principal] = proxy
+	return nil
+}
+
+// Cast records the ballot of the voter.
+// It replaces any previous ballot of the voter.
+func (p *Proxies) Cast(voter string, ballot ...int) {
+	p.ballots[voter] = append([]int(nil), ballot...)
+}
+
+// Tally registers the ballots in the election
+// and returns the number of votes cast by proxy.
+//
+// If a ballot is invalid or if the tally would overflow (see ErrOverflow), nothing is registered.
+func (p *Proxies) Tally(e *Election) (byProxy int, err error) {
+	weights := make(map[string]int, len(p.ballots))
+	accepted := make(map[string][]int, len(p.ballots))
+	for voter, ballot := range p.ballots {
+		ballot, ok := e.accept(ballot)
+		if !ok {
+			return 0, fmt.Errorf("invalid ballot of %s", voter)
+		}
+		weights[voter] = 1
+		accepted[voter] = ballot
+	}
+	for principal, proxy := range p.proxy {
+		if _, voted := p.ballots[principal]; voted {
+			continue
+		}
+		if _, ok := weights[proxy]; ok {
+			weights[proxy]++
+			byProxy++
+		}
+	}
+	if err := e.overflows(uint(len(weights) + byProxy)); err != nil {
+		return 0, err
+	}
+
+	defer e.publish()
+	for _, voter := range sortedVoters(weights) {
+		if err := e.record(time.Time{}, accepted[voter], weights[voter]); err != nil {
+			return byProxy, err
+		}
+		e.cast(accepted[voter], weights[voter])
+	}
+	return byProxy, nil
+}
+
+// sortedVoters returns the voters of the weights in increasing order,
+// so that tallies do not depend on map iteration order.
+func sortedVoters(weights map[string]int) []string {
+	voters := make([]string, 0, len(weights))
+	for voter := range weights {
+		voters = append(voters, voter)
+	}
+	sort.Strings(voters)
+	return voters
+}
+
+// Delegations resolves transitive delegations (liquid democracy):
+// instead of ranking the candidates, a voter may delegate to another voter,
+// who may delegate in turn.
+//
+// A voter's own ballot always overrides its delegation.
+type Delegations struct {
+	delegate map[string]string // voter to delegate
+	ballots  map[string][]int  // voter to ballot
+}
+
+// Resolution is the outcome of the resolution of delegations.
+type Resolution struct {
+	// Weights is the effective weight of each voter who cast a ballot:
+	// its own vote plus the votes delegated to it, directly or not.
+	Weights map[string]int
+
+	// Cycles are the delegation cycles, each one starting with its smallest voter.
+	// Votes delegated into a cycle are lost.
+	Cycles [][]string
+
+	// Lost are the voters whose vote is lost, in increasing order:
+	// their delegation ends in a cycle or with a voter who neither votes nor delegates.
+	Lost []string
+}
+
+// NewDelegations returns an empty collection of ballots and delegations.
+func NewDelegations() *Delegations {
+	return &Delegations{
+		delegate: make(map[string]string),
+		ballots:  make(map[string][]int),
+	}
+}
+
+// Delegate makes voter delegate its vote to delegate.
+// It replaces any previous delegation of the voter.
+func (d *Delegations) Delegate(voter, delegate string) error {
+	if voter == delegate {
+		return errors.New("a voter cannot delegate to itself")
+	}
+	d.delegate[voter] = delegate
+	return nil
+}
+
+// Cast records the ballot of the voter.
+// It replaces any previous ballot of the voter.
+func (d *Delegations) Cast(voter string, ballot ...int) {
+	d.ballots[voter] = append([]int(nil), ballot...)
+}
+
+// Resolve computes the effective weight of each ballot.
+func (d *Delegations) Resolve() Resolution {
+	res := Resolution{Weights: make(map[string]int, len(d.ballots))}
+	for voter := range d.ballots {
+		res.Weights[voter] = 1
+	}
+
+	// final holder of the vote of each delegating voter, "" if lost
+	final := make(map[string]string)
+	var follow func(voter string, path map[string]bool, chain []string) string
+	follow = func(voter string, path map[string]bool, chain []string) string {
+		if _, voted := d.ballots[voter]; voted {
+			return voter
+		}
+		if holder, ok := final[voter]; ok {
+			return holder
+		}
+		next, ok := d.delegate[voter]
+		if !ok {
+			return ""
+		}
+		if path[voter] {
+			// cycle from the first occurrence of voter in the chain
+			for k, v := range chain {
+				if v == voter {
+					res.Cycles = append(res.Cycles, canonicalCycle(chain[k:]))
+					break
+				}
+			}
+			return ""
+		}
+		path[voter] = true
+		holder := follow(next, path, append(chain, voter))
+		final[voter] = holder
+		return holder
+	}
+
+	delegating := make([]string, 0, len(d.delegate))
+	for voter := range d.delegate {
+		if _, voted := d.ballots[voter]; !voted {
+			delegating = append(delegating, voter)
+		}
+	}
+	sort.Strings(delegating)
+	for _, voter := range delegating {
+		holder := follow(voter, make(map[string]bool), nil)
+		if holder == "" {
+			res.Lost = append(res.Lost, voter)
+			continue
+		}
+		res.Weights[holder]++
+	}
+	return res
+}
+
+// canonicalCycle returns a copy of the cycle starting with its smallest voter.
+func canonicalCycle(cycle []string) []string {
+	start := 0
+	for k, v := range cycle {
+		if v < cycle[start] {
+			start = k
+		}
+	}
+	return append(append([]string(nil), cycle[start:]...), cycle[:start]...)
+}
+
+// Tally resolves the delegations and registers the weighted ballots in the election.
+//
+// If a ballot is invalid or if the tally would overflow (see ErrOverflow), nothing is registered.
+func (d *Delegations) Tally(e *Election) (Resolution, error) {
+	accepted := make(map[string][]int, len(d.ballots))
+	for voter, ballot := range d.ballots {
+		ballot, ok := e.accept(ballot)
+		if !ok {
+			return Resolution{}, fmt.Errorf("invalid ballot of %s", voter)
+		}
+		accepted[voter] = ballot
+	}
+
+	res := d.Resolve()
+	var total uint
+	for _, weight := range res.Weights {
+		total += uint(weight)
+	}
+	if err := e.overflows(total); err != nil {
+		return res, err
+	}
+	defer e.publish()
+	for _, voter := range sortedVoters(res.Weights) {
+		if err := e.record(time.Time{}, accepted[voter], res.Weights[voter]); err != nil {
+			return res, err
+		}
+		e.cast(accepted[voter], res.Weights[voter])
+	}
+	return res, nil
+}
@@ -0,0 +1,111 @@
+package condorcet_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestProxies_Tally checks proxy ballots and the override by the principal.
+func TestProxies_Tally(t *testing.T) {
+	p := condorcet.NewProxies()
+	p.Designate("alice", "carol")
+	p.Designate("bob", "carol")
+	p.Designate("dave", "erin") // erin does not vote
+	if err := p.Designate("carol", "carol"); err == nil {
+		t.Error("voter designated as its own proxy")
+	}
+
+	p.Cast("carol", 0, 1, 2)
+	p.Cast("bob", 2, 1, 0) // overrides carol
+	p.Cast("frank", 1, 2, 0)
+
+	e, _ := condorcet.New(3)
+	byProxy, err := p.Tally(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byProxy != 1 {
+		t.Errorf("%d votes by proxy instead of 1", byProxy)
+	}
+	if e.NumVoters() != 4 {
+		t.Errorf("%d voters instead of 4", e.NumVoters())
+	}
+
+	p.Cast("grace", 0, 0, 1)
+	if _, err := p.Tally(e); err == nil {
+		t.Error("invalid ballot accepted")
+	}
+	if e.NumVoters() != 4 {
+		t.Error("failed tally registered ballots")
+	}
+}
+
+// TestDelegations_Resolve checks transitive delegations, overrides and cycles.
+func TestDelegations_Resolve(t *testing.T) {
+	d := condorcet.NewDelegations()
+	d.Delegate("a", "b")
+	d.Delegate("b", "c") // a -> b -> c
+	d.Delegate("e", "a") // e -> a -> b -> c
+	d.Delegate("f", "g")
+	d.Delegate("g", "h")
+	d.Delegate("h", "f") // cycle
+	d.Delegate("i", "f") // into the cycle
+	d.Delegate("j", "k") // k neither votes nor delegates
+	d.Delegate("c", "a") // overridden by c's ballot
+	if err := d.Delegate("x", "x"); err == nil {
+		t.Error("voter delegated to itself")
+	}
+
+	d.Cast("c", 0, 1, 2)
+	d.Cast("d", 2, 1, 0)
+
+	e, _ := condorcet.New(3)
+	res, err := d.Tally(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Weights["c"] != 4 || res.Weights["d"] != 1 {
+		t.Errorf("unexpected weights: %v", res.Weights)
+	}
+	if len(res.Cycles) != 1 || len(res.Cycles[0]) != 3 || res.Cycles[0][0] != "f" {
+		t.Errorf("unexpected cycles: %v", res.Cycles)
+	}
+	if len(res.Lost) != 5 {
+		t.Errorf("unexpected lost votes: %v", res.Lost)
+	}
+	if e.NumVoters() != 5 {
+		t.Errorf("%d voters instead of 5", e.NumVoters())
+	}
+	if w, exist := e.Result().Winner(); !exist || w != 0 {
+		t.Errorf("unexpected winner (%d, %v)", w, exist)
+	}
+}
+
+// TestTally_overflow checks that proxies and delegations register nothing beyond the limit of the counters.
+func TestTally_overflow(t *testing.T) {
+	e, _ := condorcet.New(3, condorcet.TallyWidth(32))
+	e.VoteN(math.MaxUint32-2, 1, 0, 2)
+
+	p := condorcet.NewProxies()
+	p.Cast("alice", 0, 1, 2)
+	p.Cast("bob", 0, 1, 2)
+	p.Designate("carol", "alice")
+	if _, err := p.Tally(e); err != condorcet.ErrOverflow {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	d := condorcet.NewDelegations()
+	d.Cast("alice", 0, 1, 2)
+	d.Cast("bob", 0, 1, 2)
+	d.Delegate("carol", "bob")
+	if _, err := d.Tally(e); err != condorcet.ErrOverflow {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if e.NumVoters() != math.MaxUint32-2 {
+		t.Errorf("%d voters", e.NumVoters())
+	}
+}
@@ -0,0 +1,107 @@
+package condorcet
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+)
+
+// The tally of an election only depends on the multiset of ballots it received:
+// it is a sum of per-ballot contributions, which commute.
+// Fingerprint captures this externally observable state,
+// and CheckOrderIndependence verifies it empirically.
+
+// Fingerprint returns a hash of the tally:
+// the number of candidates, the numbers of voters and ballots (see NumBallots),
+// the sum matrix and, if stored, the profile.
+// Two results with the same tally have the same fingerprint,
+// whatever the order in which ballots were received.
+func (r Result) Fingerprint() [sha256.Size]byte {
+	h := sha256.New()
+	buf := make([]byte, binary.MaxVarintLen64)
+	write := func(x int) {
+		h.Write(buf[:binary.PutVarint(buf, int64(x))])
+	}
+
+	write(r.e.num())
+	if r.e.nota {
+		write(1)
+	} else {
+		write(0)
+	}
+	write(r.e.voters)
+	write(r.e.ballots)
+	for i := 0; i < r.e.num(); i++ {
+		for _, x := range r.row(i) {
+			write(x)
+		}
+	}
+	write(len(r.e.p))
+	for _, x := range r.e.p {
+		write(x)
+	}
+
+	var sum [sha256.Size]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+// Equal reports whether two results have the same tally.
+func (r Result) Equal(o Result) bool { return r.Fingerprint() == o.Fingerprint() }
+
+// CheckOrderIndependence ingests the ballots into elections with n candidates
+// configured by opts, first sequentially, then several times in random orders
+// spread across workers whose partial tallies are merged,
+// and checks that all tallies are identical.
+func CheckOrderIndependence(n int, ballots [][]int, workers, rounds int, seed int64, opts ...Option) error {
+	if workers < 1 {
+		return errors.New("expecting at least 1 worker")
+	}
+
+	reference, err := New(n, opts...)
+	if err != nil {
+		return err
+	}
+	for _, ballot := range ballots {
+		reference.Vote(ballot...)
+	}
+	want := reference.Result().Fingerprint()
+
+	rnd := rand.New(rand.NewSource(seed))
+	for round := 0; round < rounds; round++ {
+		order := rnd.Perm(len(ballots))
+
+		shards := make([]*Election, workers)
+		for w := range shards {
+			if shards[w], err = New(n, opts...); err != nil {
+				return err
+			}
+		}
+
+		var wg sync.WaitGroup
+		for w := range shards {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for k := w; k < len(order); k += workers {
+					shards[w].Vote(ballots[order[k]]...)
+				}
+			}(w)
+		}
+		wg.Wait()
+
+		total := shards[0].Result().e
+		for _, shard := range shards[1:] {
+			if err := total.merge(shard.Result().e); err != nil {
+				return err
+			}
+		}
+		if (Result{total}).Fingerprint() != want {
+			return fmt.Errorf("round %d: tally depends on the order of ingestion", round)
+		}
+	}
+	return nil
+}
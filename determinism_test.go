@@ -0,0 +1,56 @@
+package condorcet_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestCheckOrderIndependence checks that tallies do not depend on the order of ingestion.
+func TestCheckOrderIndependence(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	ballots := make([][]int, 500)
+	for k := range ballots {
+		ballots[k] = rnd.Perm(5)
+	}
+
+	if err := condorcet.CheckOrderIndependence(5, ballots, 4, 10, 1); err != nil {
+		t.Error(err)
+	}
+	if err := condorcet.CheckOrderIndependence(5, ballots, 3, 10, 2, condorcet.Exact()); err != nil {
+		t.Errorf("exact profile: %v", err)
+	}
+}
+
+// TestResult_Equal checks that results are equal only if tallies are.
+func TestResult_Equal(t *testing.T) {
+	e1, _ := condorcet.New(3)
+	e2, _ := condorcet.New(3)
+	e1.Vote(0, 1, 2)
+	e1.Vote(2, 1, 0)
+	e2.Vote(2, 1, 0)
+	e2.Vote(0, 1, 2)
+	if !e1.Result().Equal(e2.Result()) {
+		t.Error("same ballots in a different order give different results")
+	}
+
+	e2.Vote(1, 0, 2)
+	if e1.Result().Equal(e2.Result()) {
+		t.Error("different ballots give equal results")
+	}
+}
+
+// TestResult_EqualCounts checks that results with the same sum matrix but different counts differ.
+func TestResult_EqualCounts(t *testing.T) {
+	e1, _ := condorcet.New(3)
+	e2, _ := condorcet.New(3)
+	e1.VoteN(2, 0, 1, 2)
+	e2.VoteWeighted(2, 0, 1, 2)
+	if e1.Result().NumVoters() != e2.Result().NumVoters() {
+		t.Fatal("different numbers of voters")
+	}
+	if e1.Result().Equal(e2.Result()) {
+		t.Error("results with different numbers of ballots are equal")
+	}
+}
@@ -0,0 +1,32 @@
+package condorcet
+
+// DodgsonScores returns an approximation of the Dodgson score of each candidate:
+// the number of swaps of adjacent candidates on the ballots
+// needed for the candidate to become the Condorcet winner.
+//
+// The exact score is NP-hard to compute and requires the ballots.
+// The approximation, due to Tideman, only uses the sum matrix:
+// against each candidate it does not beat, the candidate needs enough swaps
+// to turn its deficit into a victory, each swap gaining at most one vote in one contest.
+// It is therefore a lower bound of the exact score, and the Condorcet winner, if any,
+// is the only candidate with a zero score.
+//
+// Candidates excluded by the eligibility hook have a zero score.
+func (r Result) DodgsonScores() []int {
+	scores := make([]int, r.e.num())
+	r.e.do(PhaseDodgson, func() {
+		candidates := r.e.candidates()
+		for _, i := range candidates {
+			for _, j := range candidates {
+				if i == j {
+					continue
+				}
+				// each swap reduces the deficit by 2
+				if deficit := r.e.m.at(j, i) - r.e.m.at(i, j); deficit >= 0 {
+					scores[i] += deficit/2 + 1
+				}
+			}
+		}
+	})
+	return scores
+}
@@ -0,0 +1,24 @@
+package condorcet_test
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestResult_DodgsonScores checks the approximation of the Dodgson scores.
+func TestResult_DodgsonScores(t *testing.T) {
+	// 0 loses to 1 by 10 and to 2 by 14, 1 loses to 2 by 22
+	if s := result(t, "Condorcet's example").DodgsonScores(); !reflect.DeepEqual(s, []int{6 + 8, 12, 0}) {
+		t.Errorf("unexpected scores %v", s)
+	}
+
+	// 0 loses to 2 by 10, 1 loses to 0 by 6, 2 loses to 1 by 24
+	if s := result(t, "paradoxe").DodgsonScores(); !reflect.DeepEqual(s, []int{6, 4, 13}) {
+		t.Errorf("unexpected scores %v", s)
+	}
+
+	// no vote: every contest is a tie, won with a single swap
+	if s := result(t, "no vote").DodgsonScores(); !reflect.DeepEqual(s, []int{5, 5, 5, 5, 5, 5}) {
+		t.Errorf("unexpected scores %v", s)
+	}
+}
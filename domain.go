@@ -0,0 +1,105 @@
+package condorcet
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrNoProfile is returned by analyses requiring the ballots
+// when the election does not store its profile (see Exact).
+var ErrNoProfile = errors.New("the election does not store its profile")
+
+// SingleCrossing reports whether the profile is single-crossing:
+// the distinct ballots can be ordered such that, for each pair of candidates,
+// the ballots preferring the first candidate form a prefix or a suffix of the order.
+//
+// If so, it returns the distinct ballots in such an order.
+// A single-crossing profile with an odd number of voters always has a Condorcet winner.
+//
+// It requires the full profile of the election (see Exact).
+func (r Result) SingleCrossing() (order [][]int, ok bool, err error) {
+	if r.e.p == nil {
+		return nil, false, ErrNoProfile
+	}
+
+	// distinct ballots of the profile
+	var ballots [][]int
+	for code, count := range r.e.p {
+		if count == 0 {
+			continue
+		}
+		ballot, _ := DecodeBallot(uint64(code), r.e.num())
+		ballots = append(ballots, ballot)
+	}
+	if len(ballots) == 0 {
+		return nil, true, nil
+	}
+	pos := make([][]int, len(ballots))
+	for k, ballot := range ballots {
+		pos[k] = positions(ballot)
+	}
+
+	// the farthest ballot from any ballot is an extremity of a single-crossing order
+	first := 0
+	for k := range ballots {
+		if disagreement(pos[0], pos[k]) > disagreement(pos[0], pos[first]) {
+			first = k
+		}
+	}
+
+	// sort ballots by distance to the first one
+	idx := make([]int, len(ballots))
+	dist := make([]int, len(ballots))
+	for k := range ballots {
+		idx[k] = k
+		dist[k] = disagreement(pos[first], pos[k])
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return dist[idx[a]] < dist[idx[b]] })
+
+	// disagreements with the first ballot must be nested along the order
+	for k := 1; k < len(idx); k++ {
+		prev, cur := pos[idx[k-1]], pos[idx[k]]
+		for i := 0; i < r.e.num(); i++ {
+			for j := i + 1; j < r.e.num(); j++ {
+				if disagrees(pos[first], prev, i, j) && !disagrees(pos[first], cur, i, j) {
+					return nil, false, nil
+				}
+			}
+		}
+	}
+
+	order = make([][]int, len(idx))
+	for k, i := range idx {
+		order[k] = ballots[i]
+	}
+	return order, true, nil
+}
+
+// positions returns the position of each candidate in the ballot.
+func positions(ballot []int) []int {
+	pos := make([]int, len(ballot))
+	for p, candidate := range ballot {
+		pos[candidate] = p
+	}
+	return pos
+}
+
+// disagrees reports whether two ballots, given as positions,
+// rank candidates i and j in a different order.
+func disagrees(a, b []int, i, j int) bool {
+	return (a[i] < a[j]) != (b[i] < b[j])
+}
+
+// disagreement returns the number of pairs of candidates
+// two ballots, given as positions, rank in a different order (Kendall tau distance).
+func disagreement(a, b []int) int {
+	var d int
+	for i := range a {
+		for j := i + 1; j < len(a); j++ {
+			if disagrees(a, b, i, j) {
+				d++
+			}
+		}
+	}
+	return d
+}
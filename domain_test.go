@@ -0,0 +1,53 @@
+package condorcet_test
+
+import (
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_SingleCrossing checks the detection of single-crossing profiles.
+func TestResult_SingleCrossing(t *testing.T) {
+	testcases := []struct {
+		label   string
+		ballots [][]int
+		ok      bool
+	}{
+		{
+			label:   "adjacent swaps",
+			ballots: [][]int{{2, 1, 0}, {0, 1, 2}, {1, 2, 0}, {1, 0, 2}},
+			ok:      true,
+		},
+		{
+			label:   "cycle",
+			ballots: [][]int{{0, 1, 2}, {1, 2, 0}, {2, 0, 1}},
+			ok:      false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.label, func(t *testing.T) {
+			e, _ := condorcet.New(3, condorcet.Exact())
+			for _, ballot := range tc.ballots {
+				e.Vote(ballot...)
+			}
+
+			order, ok, err := e.Result().SingleCrossing()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ok != tc.ok {
+				t.Fatalf("single-crossing is %v instead of %v", ok, tc.ok)
+			}
+			if ok && len(order) != len(tc.ballots) {
+				t.Errorf("order contains %d ballots instead of %d", len(order), len(tc.ballots))
+			}
+		})
+	}
+
+	// the profile is required
+	e, _ := condorcet.New(3)
+	if _, _, err := e.Result().SingleCrossing(); err != condorcet.ErrNoProfile {
+		t.Errorf("unexpected error without profile: %v", err)
+	}
+}
@@ -1,25 +1,87 @@
 package condorcet
 
-import "errors"
+import (
+	"errors"
+	"io"
+	"time"
+)
 
 // Election follows the Condorcet method (see https://en.wikipedia.org/wiki/Condorcet_method).
 //
 // The (pointer to) default zero value is an election with 2 candidates.
 type Election struct {
-	n int   // number of candidates - 2
-	m []int // sum matrix (row major order)
+	n      int    // number of candidates - 2
+	m      matrix // sum matrix
+	width  int    // number of bits of the counters of the sum matrix, 0 for the size of an int
+	sparse bool   // is the sum matrix sparse?
+
+	scratch bitset // scratch set of candidates, see bits
+
+	names []string   // names of the candidates, if created with NewNamed
+	meta  []Metadata // description of the candidates
+
+	voters  int // number of voters, i.e. total weight of the ballots
+	ballots int // number of ballots
+
+	nota       bool  // is the last candidate "none of the above"?
+	exact      bool  // is the full profile stored?
+	truncation bool  // may ballots rank only some candidates?
+	ties       bool  // were ballots with ties cast?
+	p          []int // number of ballots per Lehmer code, in exact mode
+	dirty      bool  // is the sum matrix out of date with the profile?
+
+	provisional map[int][]int // provisional ballots by identifier
+	nextID      int           // identifier of the next provisional ballot
+
+	stamped []stampedBallot // timestamped ballots, in order of arrival
+
+	sanitizers  []Sanitizer    // applied to every ballot before validation
+	observers   []VoteObserver // called on every ballot received
+	kemenyLimit int            // maximum number of candidates of the Kemeny-Young method, 0 for the default
+	journal     io.Writer      // write-ahead log of the accepted ballots
+
+	hook PhaseHook // optional timing of the tally phases
+
+	watchers []chan WinnerUpdate // see Watch
+	watched  WinnerUpdate        // last update sent to the watchers
+
+	eligibility EligibilityHook // optional review of the candidates at result time
+	exclusions  []Exclusion     // candidates excluded from a result
 }
 
 // New returns an election with n candidates.
 // There must be at least 2 candidates.
 //
 // Candidates are identified by an index such that 0 <= index < n.
-func New(n int) (*Election, error) {
+func New(n int, opts ...Option) (*Election, error) {
 	if n < 2 {
 		return nil, errors.New("expecting at least 2 candidates")
 	}
 
-	return &Election{n: n - 2}, nil
+	e := &Election{n: n - 2}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.nota {
+		e.n++
+	}
+	if e.exact && e.num() > MaxExactCandidates {
+		return nil, errors.New("too many candidates for an exact profile")
+	}
+	if len(e.meta) > e.num() {
+		return nil, errors.New("more metadata than candidates")
+	}
+	if e.meta != nil {
+		e.meta = append(e.meta, make([]Metadata, e.num()-len(e.meta))...)
+	}
+	if e.exact && e.truncation {
+		return nil, errors.New("exact profile requires total orders")
+	}
+	if err := e.checkWidth(); err != nil {
+		return nil, err
+	}
+
+	return e, nil
 }
 
 // num returns the number of candidates.
@@ -31,65 +93,170 @@ func (e *Election) initialized() bool { return e.m != nil }
 // init the sum matrix
 // it is an n*n matrix with no value on the diagonal
 func (e *Election) init() {
-	n := e.num()
-	e.m = make([]int, n*n)
+	e.m = e.newMatrix()
+	if e.exact {
+		e.p = make([]int, factorial(e.num()))
+	}
 }
 
-// index of the (i,j) pair in the sum matrix
-// the sum matrix is stored in row major order
-// no check is done on the values of i and j:
-//  - i!=j
-//  - 0 <= i,j < n
-func (e *Election) index(i, j int) int { return e.num()*i + j }
-
 // Vote registers the ballot.
 // First item is the prefered candidate, second is the second choice, and so on.
 //
-// The ballot must be a total order preference over all the candidates.
+// The ballot must be a total order preference over all the candidates,
+// or over some of them if truncated ballots are allowed (see AllowTruncation).
 // Otherwise the ballot is ignored and false is returned.
-func (e *Election) Vote(ballot ...int) bool {
-	// check that ballot is a total preference
-	if len(ballot) != e.num() {
-		return false
-	}
-	candidates := make([]int, e.num())
-	for _, candidate := range ballot {
-		if candidate < 0 || candidate >= e.num() {
-			return false
-		}
-		candidates[candidate]++
+// It also returns false if the ballot cannot be written to the journal (see Journal).
+func (e *Election) Vote(ballot ...int) bool { return e.vote(ballot, 1, false) == nil }
+
+// VoteN registers count identical ballots, e.g. from aggregated data.
+// The ballot is validated once, like Vote, and counted count times.
+// It returns false if count is zero or if the tally would overflow (see ErrOverflow).
+func (e *Election) VoteN(count uint, ballot ...int) bool { return e.vote(ballot, count, false) == nil }
+
+// VoteWeighted registers the ballot like Vote, with the given weight:
+// it counts as weight voters in every pairwise contest,
+// e.g. for a shareholder or a delegate.
+// The weight is counted by NumVoters, while the ballot is counted once by NumBallots.
+//
+// In an exact profile (see Exact), the weight is added to the count of the ballot.
+// It returns false if the weight is zero or if the tally would overflow (see ErrOverflow).
+func (e *Election) VoteWeighted(weight uint, ballot ...int) bool {
+	return e.vote(ballot, weight, true) == nil
+}
+
+// vote registers count identical ballots, or one ballot of weight count,
+// and notifies the observers.
+func (e *Election) vote(ballot []int, count uint, weighted bool) error {
+	err := e.tally(ballot, count, weighted)
+	e.notify(VoteEvent{Ballot: ballot, Count: int(count), Weighted: weighted, Err: err})
+	return err
+}
+
+// tally implements vote.
+func (e *Election) tally(ballot []int, count uint, weighted bool) error {
+	if count == 0 {
+		return errors.New("ballot counted zero times")
+	}
+	if err := e.overflows(count); err != nil {
+		return err
+	}
+	ballot, err := e.check(ballot)
+	if err != nil {
+		return err
 	}
-	for _, count := range candidates {
-		if count != 1 {
-			return false
+
+	if weighted {
+		if err := e.recordWeighted(time.Time{}, ballot, int(count)); err != nil {
+			return err
 		}
+		e.castWeighted(ballot, 1, int(count))
+		return nil
+	}
+	if err := e.record(time.Time{}, ballot, int(count)); err != nil {
+		return err
 	}
+	e.cast(ballot, int(count))
+	return nil
+}
 
-	if !e.initialized() {
-		e.init()
+// maxInt is the largest int.
+const maxInt = uint(^uint(0) >> 1)
+
+// ErrOverflow is returned when the tally would exceed the largest int,
+// or the limit of narrower counters (see TallyWidth).
+var ErrOverflow = errors.New("tally would overflow")
+
+// overflows returns ErrOverflow if adding weight voters would overflow the tally.
+// No entry of the sum matrix or of the profile exceeds the number of voters,
+// so checking the latter against the limit of the counters is enough.
+func (e *Election) overflows(weight uint) error {
+	if weight > e.limit()-uint(e.voters) {
+		return ErrOverflow
 	}
+	return nil
+}
 
-	// fill the sum matrix
-	for i := range ballot {
-		for j := i + 1; j < len(ballot); j++ {
-			// candidate i is prefered to candidate j
-			e.m[e.index(ballot[i], ballot[j])]++
+// VoteE registers the ballot like Vote,
+// but returns why an invalid ballot is ignored:
+// ErrWrongLength, ErrDuplicateCandidate, ErrCandidateOutOfRange, ErrOverflow,
+// or the error of a sanitizer or of the journal.
+func (e *Election) VoteE(ballot ...int) error { return e.vote(ballot, 1, false) }
+
+// VoteNE registers count identical ballots like VoteN,
+// but returns why an invalid ballot is ignored, like VoteE.
+func (e *Election) VoteNE(count uint, ballot ...int) error { return e.vote(ballot, count, false) }
+
+// accept runs the sanitizers on the ballot
+// and checks that the result is a valid preference.
+func (e *Election) accept(ballot []int) ([]int, bool) {
+	ballot, err := e.check(ballot)
+	return ballot, err == nil
+}
+
+// check implements accept and returns why the ballot is invalid.
+func (e *Election) check(ballot []int) ([]int, error) {
+	if err := e.overflows(1); err != nil {
+		return nil, err
+	}
+	for _, s := range e.sanitizers {
+		var err error
+		if ballot, err = s(ballot, e.num()); err != nil {
+			return nil, err
 		}
 	}
+	return ballot, checkOrderSet(ballot, e.num(), e.truncation, e.bits())
+}
 
-	return true
+// bits returns the scratch set of candidates of the election, empty.
+// It saves an allocation per ballot.
+func (e *Election) bits() bitset {
+	if len(e.scratch)*64 < e.num() {
+		e.scratch = newBitset(e.num())
+	}
+	return e.scratch
 }
 
-// NumVoters returns the number of voters so far.
-func (e *Election) NumVoters() int {
+// valid checks that the ballot is a total order over the candidates,
+// or the top of one if truncated ballots are allowed.
+func (e *Election) valid(ballot []int) bool {
+	return checkOrderSet(ballot, e.num(), e.truncation, e.bits()) == nil
+}
+
+// cast counts the valid ballot count times,
+// in the profile or in the sum matrix.
+func (e *Election) cast(ballot []int, count int) { e.castWeighted(ballot, count, count) }
+
+// castWeighted counts ballots copies of the valid ballot,
+// with a total weight of weight, in the profile or in the sum matrix.
+func (e *Election) castWeighted(ballot []int, ballots, weight int) {
 	if !e.initialized() {
-		return 0
+		e.init()
 	}
+	e.ballots += ballots
+	e.voters += weight
 
-	// the election has at least candidates 0 and 1
-	return e.m[e.index(0, 1)] + e.m[e.index(1, 0)]
+	if e.exact {
+		e.p[encode(ballot)] += weight
+		e.dirty = true
+		return
+	}
+
+	e.add(ballot, weight)
 }
 
+// add counts the ballot count times in the sum matrix.
+// The ballot must be valid and the matrix initialized.
+// Candidates missing from a truncated ballot are tied last.
+func (e *Election) add(ballot []int, count int) { e.m.addBallot(ballot, count) }
+
+// NumVoters returns the number of voters so far,
+// i.e. the total weight of the ballots (see VoteWeighted).
+func (e *Election) NumVoters() int { return e.voters }
+
+// NumBallots returns the number of ballots so far.
+// It is the number of voters unless ballots are weighted (see VoteWeighted).
+func (e *Election) NumBallots() int { return e.ballots }
+
 // Result returns the a snapshot of the election.
 // The election can continue receiving votes without
 // impacting previously created results.
@@ -97,12 +264,32 @@ func (e *Election) Result() Result {
 	if !e.initialized() {
 		e.init()
 	}
+	e.sync()
 
 	// copy the content of the election into the result
-	cp := &Election{}
-	cp.n = e.n
-	cp.m = make([]int, len(e.m))
-	copy(cp.m, e.m)
+	var cp *Election
+	e.do(PhaseSnapshot, func() { cp = e.clone() })
+	cp.review()
 
 	return Result{cp}
 }
+
+// clone returns a deep copy of the initialized election.
+func (e *Election) clone() *Election {
+	cp := *e
+	cp.journal = nil // snapshots never write to the journal
+	cp.scratch = nil // nor share memory with the election
+	cp.m = e.m.clone()
+	if e.p != nil {
+		cp.p = make([]int, len(e.p))
+		copy(cp.p, e.p)
+	}
+	cp.stamped = append([]stampedBallot(nil), e.stamped...)
+	if e.provisional != nil {
+		cp.provisional = make(map[int][]int, len(e.provisional))
+		for id, ballot := range e.provisional {
+			cp.provisional[id] = ballot
+		}
+	}
+	return &cp
+}
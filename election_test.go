@@ -1,6 +1,7 @@
 package condorcet_test
 
 import (
+	"bytes"
 	"strconv"
 	"testing"
 
@@ -170,6 +171,48 @@ var testcases = []struct {
 		},
 		hasWinner: false,
 	},
+	{
+		// example from
+		// https://en.wikipedia.org/wiki/Schulze_method
+		// A, B, C, D, E are 0, 1, 2, 3, 4
+		label: "Schulze's example",
+		num:   5,
+		ballots: [][]int{
+			[]int{
+				5,
+				0, 2, 1, 4, 3,
+			},
+			[]int{
+				5,
+				0, 3, 4, 2, 1,
+			},
+			[]int{
+				8,
+				1, 4, 3, 0, 2,
+			},
+			[]int{
+				3,
+				2, 0, 1, 4, 3,
+			},
+			[]int{
+				7,
+				2, 0, 4, 1, 3,
+			},
+			[]int{
+				2,
+				2, 1, 0, 3, 4,
+			},
+			[]int{
+				7,
+				3, 2, 4, 1, 0,
+			},
+			[]int{
+				8,
+				4, 1, 0, 3, 2,
+			},
+		},
+		hasWinner: false,
+	},
 }
 
 func TestElection_Winner(t *testing.T) {
@@ -253,3 +296,152 @@ func TestElection_NumVoters(t *testing.T) {
 		)
 	}
 }
+
+// TestElection_AllowTruncation checks that truncated ballots rank the missing candidates last.
+func TestElection_AllowTruncation(t *testing.T) {
+	if _, err := condorcet.New(3, condorcet.Exact(), condorcet.AllowTruncation()); err == nil {
+		t.Error("exact profile accepted with truncated ballots")
+	}
+	if e, _ := condorcet.New(3); e.Vote(2) {
+		t.Error("truncated ballot accepted by default")
+	}
+
+	e, _ := condorcet.New(3, condorcet.AllowTruncation())
+	for _, ballot := range [][]int{{}, {0, 0}, {3}, {0, 1, 2, 0}} {
+		if e.Vote(ballot...) {
+			t.Errorf("invalid ballot %v accepted", ballot)
+		}
+	}
+	for _, ballot := range [][]int{{2}, {0, 1}, {2, 1}} {
+		if !e.Vote(ballot...) {
+			t.Errorf("truncated ballot %v rejected", ballot)
+		}
+	}
+	if n := e.NumVoters(); n != 3 {
+		t.Errorf("%d voters instead of 3", n)
+	}
+
+	// 2 beats 0 and 1 by 2 to 1
+	r := e.Result()
+	if w, ok := r.Winner(); !ok || w != 2 {
+		t.Errorf("winner is (%d, %v) instead of 2", w, ok)
+	}
+	if err := r.Verify(); err != nil {
+		t.Errorf("unexpected verification error: %v", err)
+	}
+}
+
+// TestElection_VoteWeighted checks that weights count as voters but not as ballots.
+func TestElection_VoteWeighted(t *testing.T) {
+	var journal bytes.Buffer
+	e, _ := condorcet.New(3, condorcet.Journal(&journal))
+	if e.VoteWeighted(0, 0, 1, 2) {
+		t.Error("ballot with zero weight accepted")
+	}
+	if e.VoteWeighted(2, 0, 1) {
+		t.Error("invalid weighted ballot accepted")
+	}
+	e.VoteWeighted(3, 0, 1, 2)
+	e.Vote(1, 0, 2)
+	e.Vote(1, 0, 2)
+
+	// 0 beats 1 by 3 to 2
+	r := e.Result()
+	if r.NumVoters() != 5 || r.NumBallots() != 3 {
+		t.Errorf("(%d voters, %d ballots) instead of (5, 3)", r.NumVoters(), r.NumBallots())
+	}
+	if w, ok := r.Winner(); !ok || w != 0 {
+		t.Errorf("winner is (%d, %v) instead of 0", w, ok)
+	}
+
+	replayed, _ := condorcet.New(3)
+	if _, err := replayed.Replay(&journal); err != nil {
+		t.Fatal(err)
+	}
+	if !replayed.Result().Equal(r) || replayed.NumBallots() != 3 {
+		t.Error("replayed tally differs from the original one")
+	}
+}
+
+// TestElection_VoteN checks that batches are tallied like repeated ballots.
+func TestElection_VoteN(t *testing.T) {
+	for _, tc := range testcases {
+		batched, _ := condorcet.New(tc.num)
+		repeated, _ := condorcet.New(tc.num)
+		for _, ballot := range tc.ballots {
+			if !batched.VoteN(uint(ballot[0]), ballot[1:]...) {
+				t.Fatalf("%s: valid batch rejected", tc.label)
+			}
+			for k := 0; k < ballot[0]; k++ {
+				repeated.Vote(ballot[1:]...)
+			}
+		}
+		if !batched.Result().Equal(repeated.Result()) || batched.NumBallots() != repeated.NumBallots() {
+			t.Errorf("%s: batched tally differs from repeated ballots", tc.label)
+		}
+	}
+
+	e, _ := condorcet.New(3)
+	if e.VoteN(0, 0, 1, 2) || e.VoteN(2, 0, 1) {
+		t.Error("invalid batch accepted")
+	}
+}
+
+// TestElection_VoteE checks the errors of invalid ballots.
+func TestElection_VoteE(t *testing.T) {
+	e, _ := condorcet.New(3)
+	testcases := []struct {
+		ballot []int
+		err    error
+	}{
+		{[]int{0, 1, 2}, nil},
+		{[]int{0, 1}, condorcet.ErrWrongLength},
+		{[]int{0, 1, 2, 0}, condorcet.ErrWrongLength},
+		{[]int{0, 1, 1}, condorcet.ErrDuplicateCandidate},
+		{[]int{0, 1, 3}, condorcet.ErrCandidateOutOfRange},
+		{[]int{-1, 1, 2}, condorcet.ErrCandidateOutOfRange},
+	}
+	for _, tc := range testcases {
+		if err := e.VoteE(tc.ballot...); err != tc.err {
+			t.Errorf("ballot %v: error %v instead of %v", tc.ballot, err, tc.err)
+		}
+	}
+	if n := e.NumVoters(); n != 1 {
+		t.Errorf("%d voters instead of 1", n)
+	}
+}
+
+// TestElection_Overflow checks that the tally refuses to overflow.
+func TestElection_Overflow(t *testing.T) {
+	const maxInt = uint(^uint(0) >> 1)
+
+	e, _ := condorcet.New(3)
+	if !e.VoteN(maxInt-1, 0, 1, 2) {
+		t.Fatal("largest count refused")
+	}
+	if e.VoteWeighted(2, 0, 1, 2) || e.VoteN(2, 0, 1, 2) {
+		t.Error("overflowing ballot accepted")
+	}
+	if !e.VoteN(1, 2, 1, 0) || e.NumVoters() != int(maxInt) {
+		t.Errorf("%d voters instead of the largest int", e.NumVoters())
+	}
+	if err := e.VoteE(0, 1, 2); err != condorcet.ErrOverflow {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	o, _ := condorcet.New(3)
+	o.Vote(0, 1, 2)
+	if err := e.Merge(o); err != condorcet.ErrOverflow {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+// BenchmarkElection_Vote measures the cost of a ballot for 10 candidates.
+func BenchmarkElection_Vote(b *testing.B) {
+	e, _ := condorcet.New(10)
+	ballot := []int{3, 1, 4, 0, 5, 9, 2, 6, 8, 7}
+	b.ReportAllocs()
+	for k := 0; k < b.N; k++ {
+		e.Vote(ballot...)
+	}
+}
@@ -0,0 +1,60 @@
+package condorcet
+
+// EligibilityHook reviews a candidate when a result is created.
+// A non-nil error disqualifies the candidate and documents the reason.
+type EligibilityHook func(candidate int) error
+
+// Exclusion documents a candidate excluded from a result.
+type Exclusion struct {
+	Candidate int
+	Reason    string
+}
+
+// SetEligibilityHook registers a hook reviewing every candidate
+// each time a result is created.
+// A nil hook disables the review.
+//
+// Disqualified candidates are excluded from the outcome of the result,
+// as if the pairwise matrix were restricted to the eligible candidates.
+// Candidates keep their index.
+func (e *Election) SetEligibilityHook(h EligibilityHook) { e.eligibility = h }
+
+// review runs the eligibility hook on every candidate of the snapshot.
+func (e *Election) review() {
+	e.exclusions = nil
+	if e.eligibility == nil {
+		return
+	}
+	for c := 0; c < e.num(); c++ {
+		if err := e.eligibility(c); err != nil {
+			e.exclusions = append(e.exclusions, Exclusion{c, err.Error()})
+		}
+	}
+}
+
+// eligible reports whether the candidate takes part in the outcome.
+func (e *Election) eligible(candidate int) bool {
+	for _, x := range e.exclusions {
+		if x.Candidate == candidate {
+			return false
+		}
+	}
+	return true
+}
+
+// candidates returns the eligible candidates in increasing order.
+func (e *Election) candidates() []int {
+	candidates := make([]int, 0, e.num())
+	for c := 0; c < e.num(); c++ {
+		if e.eligible(c) {
+			candidates = append(candidates, c)
+		}
+	}
+	return candidates
+}
+
+// Exclusions returns the candidates excluded from the result
+// by the eligibility hook or withdrawn (see Withdraw), in increasing order of index.
+func (r Result) Exclusions() []Exclusion {
+	return append([]Exclusion(nil), r.e.exclusions...)
+}
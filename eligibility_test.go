@@ -0,0 +1,42 @@
+package condorcet_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestElection_SetEligibilityHook disqualifies the Condorcet winner
+// and checks the result is computed among eligible candidates.
+func TestElection_SetEligibilityHook(t *testing.T) {
+	e, _ := condorcet.New(3)
+	e.Vote(2, 0, 1)
+	e.Vote(2, 1, 0)
+	e.Vote(0, 1, 2)
+
+	if w, exist := e.Result().Winner(); !exist || w != 2 {
+		t.Fatalf("unexpected winner (%d, %v) before review", w, exist)
+	}
+
+	e.SetEligibilityHook(func(candidate int) error {
+		if candidate == 2 {
+			return errors.New("failed eligibility review")
+		}
+		return nil
+	})
+	r := e.Result()
+	if w, exist := r.Winner(); !exist || w != 0 {
+		t.Errorf("unexpected winner (%d, %v) after review", w, exist)
+	}
+	x := r.Exclusions()
+	if len(x) != 1 || x[0].Candidate != 2 || x[0].Reason != "failed eligibility review" {
+		t.Errorf("unexpected exclusions: %+v", x)
+	}
+	if _, ok := r.Deficit(2); ok {
+		t.Error("excluded candidate has a deficit")
+	}
+	if r.NumVoters() != 3 {
+		t.Errorf("%d voters instead of 3", r.NumVoters())
+	}
+}
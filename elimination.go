@@ -0,0 +1,112 @@
+package condorcet
+
+// Round is a round of an elimination method.
+type Round struct {
+	Scores     []int `json:"scores"`     // score of the candidates still running, indexed by candidate
+	Eliminated []int `json:"eliminated"` // candidates eliminated at the end of the round
+
+	// Participation of the ballots in the round,
+	// nil if the ballots are not stored (see Exact).
+	Participation *Participation `json:"participation"`
+}
+
+// Participation is the participation of the ballots in a round of an elimination method.
+//
+// Exact profiles only store total orders, which rank every running candidate:
+// Exhausted is always 0 with them.
+type Participation struct {
+	Active      int `json:"active"`      // ballots ranking a running candidate
+	Exhausted   int `json:"exhausted"`   // ballots ranking no running candidate
+	Transferred int `json:"transferred"` // ballots whose preferred running candidate was eliminated in the previous round
+}
+
+// participation returns the participation of the ballots in a round over the running candidates,
+// the previous round being over the candidates of previous, nil for the first round.
+// It returns nil if the ballots are not stored.
+func (r Result) participation(running, previous []bool) *Participation {
+	if r.e.p == nil {
+		return nil
+	}
+
+	first := func(ballot []int, running []bool) int {
+		for _, c := range ballot {
+			if running[c] {
+				return c
+			}
+		}
+		return -1
+	}
+	p := new(Participation)
+	for code, count := range r.e.p {
+		if count == 0 {
+			continue
+		}
+		ballot, _ := DecodeBallot(uint64(code), r.e.num())
+		top := first(ballot, running)
+		switch {
+		case top < 0:
+			p.Exhausted += count
+		case previous != nil && first(ballot, previous) != top:
+			p.Active += count
+			p.Transferred += count
+		default:
+			p.Active += count
+		}
+	}
+	return p
+}
+
+// newRound returns a round over the running candidates, the previous round being over the candidates of previous.
+func (r Result) newRound(scores, eliminated []int, running, previous []bool) Round {
+	return Round{Scores: scores, Eliminated: eliminated, Participation: r.participation(running, previous)}
+}
+
+// eliminate runs an elimination method over the candidates:
+// at each round, the candidates with the lowest score are eliminated,
+// until a single candidate remains or all remaining candidates tie.
+// score computes the scores of the running candidates.
+//
+// It returns the remaining candidates and the rounds.
+func (r Result) eliminate(candidates []int, score func(running []bool) []int) (winners []int, rounds []Round) {
+	running := make([]bool, r.e.num())
+	for _, c := range candidates {
+		running[c] = true
+	}
+
+	var previous []bool
+	for {
+		winners = winners[:0]
+		for c, ok := range running {
+			if ok {
+				winners = append(winners, c)
+			}
+		}
+		if len(winners) <= 1 {
+			return winners, rounds
+		}
+
+		scores := score(running)
+		lowest := scores[winners[0]]
+		for _, c := range winners {
+			if scores[c] < lowest {
+				lowest = scores[c]
+			}
+		}
+		var eliminated []int
+		for _, c := range winners {
+			if scores[c] == lowest {
+				eliminated = append(eliminated, c)
+			}
+		}
+		if len(eliminated) == len(winners) {
+			rounds = append(rounds, r.newRound(scores, nil, running, previous))
+			return winners, rounds
+		}
+
+		rounds = append(rounds, r.newRound(scores, eliminated, running, previous))
+		previous = append(previous[:0], running...)
+		for _, c := range eliminated {
+			running[c] = false
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Package feed serves a public, read-only JSON feed of election results.
+//
+// The feed only exposes aggregated figures, never the ballots,
+// and is designed to be embedded in third-party sites:
+// responses carry an ETag and conditional requests are answered with 304 Not Modified.
+package feed
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// Document is the JSON document served by the feed.
+type Document struct {
+	Candidates int  `json:"candidates"`
+	Voters     int  `json:"voters"`
+	Winner     *int `json:"winner"` // null if there is no winner
+}
+
+// NewDocument returns the sanitized document of the result.
+func NewDocument(r condorcet.Result) Document {
+	doc := Document{
+		Candidates: r.NumCandidates(),
+		Voters:     r.NumVoters(),
+	}
+	if w, exist := r.Winner(); exist {
+		doc.Winner = &w
+	}
+	return doc
+}
+
+// Handler returns a handler serving the result returned by source.
+// source is called on each request.
+//
+// Only GET and HEAD requests are allowed.
+func Handler(source func() condorcet.Result) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := json.Marshal(NewDocument(source()))
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		sum := sha256.Sum256(body)
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+		h := w.Header()
+		h.Set("ETag", etag)
+		h.Set("Cache-Control", "public, no-cache")
+		h.Set("Access-Control-Allow-Origin", "*")
+
+		if req.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		h.Set("Content-Type", "application/json")
+		if req.Method == http.MethodHead {
+			return
+		}
+		w.Write(body)
+	})
+}
@@ -0,0 +1,57 @@
+package feed_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/feed"
+)
+
+// TestHandler asserts that the feed serves the result and honours If-None-Match.
+func TestHandler(t *testing.T) {
+	e, _ := condorcet.New(3)
+	e.Vote(2, 0, 1)
+	h := feed.Handler(e.Result)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d", rec.Code)
+	}
+
+	var doc feed.Document
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid document: %v", err)
+	}
+	if doc.Candidates != 3 || doc.Voters != 1 || doc.Winner == nil || *doc.Winner != 2 {
+		t.Errorf("unexpected document: %+v", doc)
+	}
+
+	// same result: not modified
+	etag := rec.Header().Get("ETag")
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("unexpected status %d for a fresh ETag", rec.Code)
+	}
+
+	// new vote: modified
+	e.Vote(1, 0, 2)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("unexpected status %d for a stale ETag", rec.Code)
+	}
+
+	// read-only
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("unexpected status %d for POST", rec.Code)
+	}
+}
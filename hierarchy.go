@@ -0,0 +1,61 @@
+package condorcet
+
+import "fmt"
+
+// Node is a level of a hierarchy of elections,
+// e.g. precinct, district and jurisdiction.
+// Each node owns the ballots cast at its level,
+// and its result aggregates the ballots of all its descendants.
+type Node struct {
+	Name     string
+	Election *Election // ballots cast at this level, may be nil
+	Children []*Node
+}
+
+// Find returns the descendant of the node at the given path of names,
+// or nil if there is none.
+// The empty path is the node itself.
+func (nd *Node) Find(path ...string) *Node {
+	if len(path) == 0 {
+		return nd
+	}
+	for _, child := range nd.Children {
+		if child.Name == path[0] {
+			return child.Find(path[1:]...)
+		}
+	}
+	return nil
+}
+
+// Result returns the aggregated result of the node and its descendants.
+// All elections of the hierarchy must have the same candidates.
+//
+// It fails if no election is found in the hierarchy.
+func (nd *Node) Result() (Result, error) {
+	var agg *Election
+	var walk func(nd *Node) error
+	walk = func(nd *Node) error {
+		if nd.Election != nil {
+			r := nd.Election.Result()
+			if agg == nil {
+				agg = r.e
+			} else if err := agg.merge(r.e); err != nil {
+				return fmt.Errorf("%s: %v", nd.Name, err)
+			}
+		}
+		for _, child := range nd.Children {
+			if err := walk(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(nd); err != nil {
+		return Result{}, err
+	}
+	if agg == nil {
+		return Result{}, fmt.Errorf("%s: no election in the hierarchy", nd.Name)
+	}
+	return Result{agg}, nil
+}
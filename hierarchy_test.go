@@ -0,0 +1,68 @@
+package condorcet_test
+
+import (
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestNode_Result aggregates precincts into districts and a jurisdiction.
+func TestNode_Result(t *testing.T) {
+	precinct := func(name string, ballots ...[]int) *condorcet.Node {
+		e, _ := condorcet.New(3)
+		for _, ballot := range ballots {
+			e.Vote(ballot...)
+		}
+		return &condorcet.Node{Name: name, Election: e}
+	}
+
+	root := &condorcet.Node{
+		Name: "state",
+		Children: []*condorcet.Node{
+			{
+				Name: "north",
+				Children: []*condorcet.Node{
+					precinct("n1", []int{0, 1, 2}, []int{0, 2, 1}),
+					precinct("n2", []int{1, 0, 2}),
+				},
+			},
+			{
+				Name: "south",
+				Children: []*condorcet.Node{
+					precinct("s1", []int{1, 2, 0}, []int{1, 0, 2}),
+				},
+			},
+		},
+	}
+
+	r, err := root.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.NumVoters() != 5 {
+		t.Errorf("%d voters instead of 5", r.NumVoters())
+	}
+	if w, exist := r.Winner(); !exist || w != 1 {
+		t.Errorf("unexpected winner (%d, %v) at the top level", w, exist)
+	}
+
+	north, err := root.Find("north").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w, exist := north.Winner(); !exist || w != 0 {
+		t.Errorf("unexpected winner (%d, %v) in the north", w, exist)
+	}
+
+	// aggregation does not modify the precincts
+	if n := root.Find("north", "n1").Election.NumVoters(); n != 2 {
+		t.Errorf("precinct modified by the aggregation: %d voters", n)
+	}
+
+	// incompatible precinct
+	e, _ := condorcet.New(4)
+	root.Children = append(root.Children, &condorcet.Node{Name: "bad", Election: e})
+	if _, err := root.Result(); err == nil {
+		t.Error("aggregation of incompatible elections did not fail")
+	}
+}
@@ -0,0 +1,234 @@
+package condorcet
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The journal is a write-ahead log of the accepted ballots.
+// Each ballot is written before it is tallied, and synced if the writer supports it (e.g. *os.File),
+// so that a crash loses at most the ballot being written.
+// Operations which cannot be replayed from the ballots,
+// such as Remap, MergeCandidates and Merge, are refused with ErrJournaled.
+//
+// A record is a line of space separated integers:
+// the number of times the ballot is counted followed by the ballot.
+// Tied candidates (see VoteRanked) are separated by = instead of a space.
+// The count of a weighted ballot (see VoteWeighted) is its weight suffixed with w.
+// A record made of a single + is the addition of a candidate (see AddCandidate).
+// Timestamped ballots (see VoteAt) are prefixed with @ and the time in Unix nanoseconds.
+
+// Journal makes the election write every accepted ballot to w before tallying it.
+// If the write fails, the ballot is rejected.
+//
+// Only the ballots received after creation are written:
+// use Replay or OpenJournal to recover a tally from a journal.
+func Journal(w io.Writer) Option {
+	return func(e *Election) { e.journal = w }
+}
+
+// ErrJournaled is returned by the operations which cannot be written to the journal (see Journal).
+var ErrJournaled = errors.New("operation cannot be written to the journal")
+
+// syncer is implemented by writers which can commit their content to stable storage, e.g. *os.File.
+type syncer interface {
+	Sync() error
+}
+
+// commit writes the record to the journal, and syncs it if possible.
+func (e *Election) commit(record []byte) error {
+	if _, err := e.journal.Write(record); err != nil {
+		return err
+	}
+	if s, ok := e.journal.(syncer); ok {
+		return s.Sync()
+	}
+	return nil
+}
+
+// record writes the ballot to the journal, if any.
+func (e *Election) record(t time.Time, ballot []int, count int) error {
+	if e.journal == nil {
+		return nil
+	}
+
+	return e.write(t, singletons(ballot), strconv.Itoa(count))
+}
+
+// recordWeighted writes the weighted ballot to the journal, if any.
+func (e *Election) recordWeighted(t time.Time, ballot []int, weight int) error {
+	if e.journal == nil {
+		return nil
+	}
+	return e.write(t, singletons(ballot), strconv.Itoa(weight)+"w")
+}
+
+// recordRanked writes the ballot with ties to the journal, if any.
+func (e *Election) recordRanked(t time.Time, groups [][]int, count int) error {
+	if e.journal == nil {
+		return nil
+	}
+	return e.write(t, groups, strconv.Itoa(count))
+}
+
+// singletons returns the groups of a ballot without ties.
+func singletons(ballot []int) [][]int {
+	groups := make([][]int, len(ballot))
+	for k := range ballot {
+		groups[k] = ballot[k : k+1]
+	}
+	return groups
+}
+
+// write writes a record to the journal.
+func (e *Election) write(t time.Time, groups [][]int, count string) error {
+	var buf bytes.Buffer
+	if !t.IsZero() {
+		buf.WriteString("@" + strconv.FormatInt(t.UnixNano(), 10) + " ")
+	}
+	buf.WriteString(count)
+	for _, g := range groups {
+		for k, c := range g {
+			if k == 0 {
+				buf.WriteByte(' ')
+			} else {
+				buf.WriteByte('=')
+			}
+			buf.WriteString(strconv.Itoa(c))
+		}
+	}
+	buf.WriteByte('\n')
+
+	return e.commit(buf.Bytes())
+}
+
+// Replay tallies the ballots of a journal, without writing them again.
+// An incomplete last record, i.e. a ballot which was being written during a crash, is ignored.
+//
+// It returns the length in bytes of the complete records.
+func (e *Election) Replay(r io.Reader) (int64, error) {
+	defer e.publish()
+	br := bufio.NewReader(r)
+	var size int64
+	for line := 1; ; line++ {
+		text, err := br.ReadString('\n')
+		if err == io.EOF {
+			return size, nil // incomplete or no record
+		}
+		if err != nil {
+			return size, err
+		}
+
+		if text == "+\n" {
+			e.addCandidate()
+			size += int64(len(text))
+			continue
+		}
+
+		t, groups, count, weighted, err := parseRecord(strings.TrimSuffix(text, "\n"))
+		if err != nil {
+			return size, fmt.Errorf("journal line %d: %v", line, err)
+		}
+		if !e.validRanked(groups) {
+			return size, fmt.Errorf("journal line %d: invalid ballot", line)
+		}
+		var ballot []int
+		tied := false
+		for _, g := range groups {
+			ballot = append(ballot, g...)
+			tied = tied || len(g) != 1
+		}
+		if tied && (weighted || !t.IsZero()) {
+			return size, fmt.Errorf("journal line %d: weighted or timestamped ballot with ties", line)
+		}
+		switch {
+		case tied:
+			e.castRanked(groups, count)
+		case weighted && count < 0:
+			e.castWeighted(ballot, -1, count) // see UnvoteWeighted
+		case weighted:
+			e.castWeighted(ballot, 1, count)
+		default:
+			e.cast(ballot, count)
+			if count < 0 {
+				e.unstamp(ballot, -count) // see UnvoteN
+			}
+		}
+		if !t.IsZero() {
+			e.stamped = append(e.stamped, stampedBallot{t, ballot})
+		}
+		size += int64(len(text))
+	}
+}
+
+// parseRecord parses a record of the journal.
+// The ballot is returned as groups of tied candidates.
+func parseRecord(text string) (t time.Time, groups [][]int, count int, weighted bool, err error) {
+	fields := strings.Fields(text)
+	if len(fields) > 0 && strings.HasPrefix(fields[0], "@") {
+		ns, err := strconv.ParseInt(fields[0][1:], 10, 64)
+		if err != nil {
+			return t, nil, 0, false, errors.New("invalid timestamp")
+		}
+		t = time.Unix(0, ns)
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return t, nil, 0, false, errors.New("empty record")
+	}
+
+	weighted = strings.HasSuffix(fields[0], "w")
+	if count, err = strconv.Atoi(strings.TrimSuffix(fields[0], "w")); err != nil {
+		return t, nil, 0, false, errors.New("invalid count")
+	}
+	groups = make([][]int, len(fields)-1)
+	for k, f := range fields[1:] {
+		for _, s := range strings.Split(f, "=") {
+			c, err := strconv.Atoi(s)
+			if err != nil {
+				return t, nil, 0, false, errors.New("invalid candidate")
+			}
+			groups[k] = append(groups[k], c)
+		}
+	}
+	return t, groups, count, weighted, nil
+}
+
+// OpenJournal returns an election with n candidates journaled to the file at path.
+// If the file exists, its ballots are first replayed and an incomplete last record is discarded.
+//
+// The caller must close the returned file when the election is over.
+func OpenJournal(path string, n int, opts ...Option) (*Election, *os.File, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	e, err := New(n, append(opts, Journal(f))...)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	size, err := e.Replay(f)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	if err := f.Truncate(size); err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	if _, err := f.Seek(size, io.SeekStart); err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return e, f, nil
+}
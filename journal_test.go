@@ -0,0 +1,105 @@
+package condorcet_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestOpenJournal simulates a crash during a write and recovers the tally.
+func TestOpenJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "condorcet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "election.wal")
+
+	e, f, err := condorcet.OpenJournal(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Vote(0, 1, 2)
+	e.Vote(0, 2, 1)
+	e.VoteAt(time.Unix(100, 0), 2, 1, 0)
+	id, _ := e.VoteProvisional(1, 0, 2)
+	e.Accept(id)
+	want := e.Result()
+
+	// crash while writing a ballot
+	f.WriteString("1 2 0")
+	f.Close()
+
+	e, f, err = condorcet.OpenJournal(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if !e.Result().Equal(want) {
+		t.Error("recovered tally differs from the tally before the crash")
+	}
+	if r := e.ResultAsOf(time.Unix(50, 0)); r.NumVoters() != 3 {
+		t.Errorf("timestamps are not recovered: %d voters as of the cutoff", r.NumVoters())
+	}
+
+	// the election goes on after recovery
+	e.Vote(1, 2, 0)
+	f.Close()
+	e, f, err = condorcet.OpenJournal(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if e.NumVoters() != 5 {
+		t.Errorf("%d voters after second recovery instead of 5", e.NumVoters())
+	}
+}
+
+// syncBuffer counts the syncs of the records written to it.
+type syncBuffer struct {
+	bytes.Buffer
+	syncs int
+}
+
+func (b *syncBuffer) Sync() error {
+	b.syncs++
+	return nil
+}
+
+// TestJournal_sync checks that the records are synced and that the operations
+// which cannot be replayed are refused.
+func TestJournal_sync(t *testing.T) {
+	var w syncBuffer
+	e, _ := condorcet.New(3, condorcet.Journal(&w))
+	e.Vote(0, 1, 2)
+	e.AddCandidate()
+	if w.syncs != 2 {
+		t.Errorf("%d syncs instead of 2", w.syncs)
+	}
+
+	other, _ := condorcet.New(4)
+	other.Vote(3, 2, 1, 0)
+	if err := e.Merge(other); err != condorcet.ErrJournaled {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := e.Remap([]int{3, 2, 1, 0}); err != condorcet.ErrJournaled {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := e.MergeCandidates(0, 1); err != condorcet.ErrJournaled {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	// the journal still matches the tally
+	replayed, _ := condorcet.New(3)
+	if _, err := replayed.Replay(&w.Buffer); err != nil {
+		t.Fatal(err)
+	}
+	if !replayed.Result().Equal(e.Result()) {
+		t.Error("replayed tally differs")
+	}
+}
@@ -0,0 +1,228 @@
+package condorcet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// resultJSON is the JSON representation of a result.
+type resultJSON struct {
+	Candidates int     `json:"candidates"`
+	Voters     int     `json:"voters"`
+	Pairwise   [][]int `json:"pairwise"`
+	Winner     *int    `json:"winner"`
+}
+
+// MarshalJSON implements json.Marshaler:
+//
+//	{"candidates": n, "voters": v, "pairwise": [[...]], "winner": w}
+//
+// where pairwise[i][j] is the number of voters preferring i to j,
+// and winner is null if there is no winner.
+func (r Result) MarshalJSON() ([]byte, error) {
+	doc := resultJSON{
+		Candidates: r.e.num(),
+		Voters:     r.NumVoters(),
+		Pairwise:   r.Matrix(),
+	}
+	if w, exist := r.Winner(); exist {
+		doc.Winner = &w
+	}
+	return json.Marshal(doc)
+}
+
+// ElectionJSONVersion is the version of the JSON schema of an election (see Election.MarshalJSON).
+const ElectionJSONVersion = 1
+
+// electionState is the persisted state of an election (see MarshalJSON and MarshalBinary).
+type electionState struct {
+	Version     int                `json:"version"`
+	Candidates  int                `json:"candidates"`
+	NOTA        bool               `json:"nota,omitempty"`
+	Truncation  bool               `json:"truncation,omitempty"`
+	Ties        bool               `json:"ties,omitempty"`
+	Exact       bool               `json:"exact,omitempty"`
+	Voters      int                `json:"voters"`
+	Ballots     int                `json:"ballots"`
+	Pairwise    [][]int            `json:"pairwise,omitempty"`
+	Profile     []int              `json:"profile,omitempty"`
+	Names       []string           `json:"names,omitempty"`
+	Metadata    []Metadata         `json:"metadata,omitempty"`
+	Provisional []provisionalState `json:"provisional,omitempty"`
+	NextID      int                `json:"next_id,omitempty"`
+	Stamped     []stampedState     `json:"stamped,omitempty"`
+	Exclusions  []Exclusion        `json:"-"` // only persisted by results
+}
+
+// provisionalState is the persisted state of a provisional ballot.
+type provisionalState struct {
+	ID     int   `json:"id"`
+	Ballot []int `json:"ballot"`
+}
+
+// stampedState is the persisted state of a timestamped ballot.
+type stampedState struct {
+	Time   time.Time `json:"time"`
+	Ballot []int     `json:"ballot"`
+}
+
+// MarshalJSON implements json.Marshaler, so that an election in progress
+// can be persisted and resumed with UnmarshalJSON:
+//
+//	{"version": 1, "candidates": n, "voters": v, "ballots": b, "pairwise": [[...]], ...}
+//
+// The document also holds the options shaping the tally (NoneOfTheAbove, AllowTruncation, Exact),
+// the profile, the names and metadata of the candidates,
+// and the provisional and timestamped ballots.
+// Sanitizers, journal, hooks and the Kemeny-Young limit are not persisted.
+func (e *Election) MarshalJSON() ([]byte, error) { return json.Marshal(e.state()) }
+
+// state returns the state of the election to persist.
+func (e *Election) state() electionState {
+	e.sync()
+	doc := electionState{
+		Version:    ElectionJSONVersion,
+		Candidates: e.num(),
+		NOTA:       e.nota,
+		Truncation: e.truncation,
+		Ties:       e.ties,
+		Exact:      e.exact,
+		Voters:     e.voters,
+		Ballots:    e.ballots,
+		Profile:    e.p,
+		Names:      e.names,
+		Metadata:   e.meta,
+		NextID:     e.nextID,
+	}
+	if e.initialized() {
+		doc.Pairwise = Result{e}.Matrix()
+	}
+	ids := make([]int, 0, len(e.provisional))
+	for id := range e.provisional {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		doc.Provisional = append(doc.Provisional, provisionalState{id, e.provisional[id]})
+	}
+	for _, s := range e.stamped {
+		doc.Stamped = append(doc.Stamped, stampedState{s.t, s.ballot})
+	}
+	return doc
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It restores the state of an election marshaled with MarshalJSON,
+// checking its consistency (see Result.Verify).
+// Sanitizers, journal and hooks of the receiver are kept, e.g.:
+//
+//	e, _ := condorcet.New(2, condorcet.Journal(w))
+//	err := json.Unmarshal(data, e)
+func (e *Election) UnmarshalJSON(data []byte) error {
+	var doc electionState
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	return e.restore(doc)
+}
+
+// restore restores the persisted state of the election, checking its consistency.
+func (e *Election) restore(doc electionState) error {
+	if doc.Version != ElectionJSONVersion {
+		return fmt.Errorf("unsupported election version %d", doc.Version)
+	}
+	n := doc.Candidates
+	if n < 2 {
+		return errors.New("expecting at least 2 candidates")
+	}
+
+	loaded := *e
+	loaded.n = n - 2
+	loaded.nota = doc.NOTA
+	loaded.truncation = doc.Truncation
+	loaded.ties = doc.Ties
+	loaded.voters = doc.Voters
+	loaded.ballots = doc.Ballots
+	loaded.names = doc.Names
+	loaded.meta = doc.Metadata
+	loaded.nextID = doc.NextID
+	loaded.exact = doc.Exact || doc.Profile != nil // the profile implied Exact before the field existed
+	loaded.dirty = false
+	loaded.m, loaded.p = nil, nil
+	loaded.provisional, loaded.stamped = nil, nil
+	loaded.exclusions = doc.Exclusions
+
+	if len(doc.Names) > n || len(doc.Metadata) > n {
+		return errors.New("more names or metadata than candidates")
+	}
+	if loaded.meta != nil {
+		loaded.meta = append(loaded.meta, make([]Metadata, n-len(loaded.meta))...)
+	}
+	if doc.Voters > 0 && uint(doc.Voters) > loaded.limit() {
+		return ErrOverflow
+	}
+	if loaded.exact && (n > MaxExactCandidates || loaded.truncation || loaded.ties) {
+		return errors.New("invalid exact profile")
+	}
+	if doc.Pairwise != nil {
+		if len(doc.Pairwise) != n {
+			return errors.New("pairwise matrix does not match the candidates")
+		}
+		loaded.init()
+		for i, row := range doc.Pairwise {
+			if len(row) != n {
+				return errors.New("pairwise matrix does not match the candidates")
+			}
+			for j, x := range row {
+				if j != i {
+					loaded.m.set(i, j, x)
+				} else if x != 0 {
+					return errors.New("non-zero diagonal in the pairwise matrix")
+				}
+			}
+		}
+		// the triangular layout only keeps consistent pairs (see newMatrix)
+		for i, row := range doc.Pairwise {
+			for j, x := range row {
+				if loaded.m.at(i, j) != x {
+					return fmt.Errorf("inconsistent pairwise counts of %d and %d", i, j)
+				}
+			}
+		}
+		if loaded.exact {
+			if uint64(len(doc.Profile)) != factorial(n) {
+				return errors.New("profile does not match the candidates")
+			}
+			copy(loaded.p, doc.Profile)
+		}
+		if err := (Result{&loaded}).Verify(); err != nil {
+			return err
+		}
+	} else if doc.Voters != 0 || doc.Ballots != 0 || doc.Profile != nil {
+		return errors.New("missing pairwise matrix")
+	} else {
+		loaded.init() // no vote yet
+	}
+
+	for _, p := range doc.Provisional {
+		if !loaded.valid(p.Ballot) || p.ID >= doc.NextID || loaded.provisional[p.ID] != nil {
+			return errors.New("invalid provisional ballot")
+		}
+		if loaded.provisional == nil {
+			loaded.provisional = make(map[int][]int)
+		}
+		loaded.provisional[p.ID] = p.Ballot
+	}
+	for _, s := range doc.Stamped {
+		if !loaded.valid(s.Ballot) {
+			return errors.New("invalid timestamped ballot")
+		}
+		loaded.stamped = append(loaded.stamped, stampedBallot{s.Time, s.Ballot})
+	}
+
+	*e = loaded
+	return nil
+}
@@ -0,0 +1,95 @@
+package condorcet_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_MarshalJSON checks the JSON representation of results.
+func TestResult_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(result(t, "Condorcet's example"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"candidates":3,"voters":60,"pairwise":[[0,25,23],[35,0,19],[37,41,0]],"winner":2}`
+	if string(b) != want {
+		t.Errorf("unexpected JSON %s", b)
+	}
+
+	b, _ = json.Marshal(result(t, "paradoxe"))
+	var doc struct{ Winner *int }
+	if err := json.Unmarshal(b, &doc); err != nil || doc.Winner != nil {
+		t.Errorf("unexpected winner in %s", b)
+	}
+}
+
+// TestElection_MarshalJSON saves an election in progress and resumes it.
+func TestElection_MarshalJSON(t *testing.T) {
+	for _, opts := range [][]condorcet.Option{nil, {condorcet.Exact()}, {condorcet.AllowTruncation()}} {
+		e, _ := condorcet.NewNamed([]string{"Alice", "Bob", "Carol"}, append(opts, condorcet.NoneOfTheAbove())...)
+		e.VoteN(3, 0, 1, 3, 2)
+		e.VoteWeighted(2, 2, 0, 1, 3)
+		e.VoteAt(time.Unix(100, 0), 1, 2, 0, 3)
+		id, _ := e.VoteProvisional(2, 1, 0, 3)
+
+		data, err := json.Marshal(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var resumed condorcet.Election
+		if err := json.Unmarshal(data, &resumed); err != nil {
+			t.Fatalf("cannot resume %s: %v", data, err)
+		}
+		if !resumed.Result().Equal(e.Result()) || resumed.NumBallots() != 5 || resumed.Name(1) != "Bob" {
+			t.Errorf("resumed election differs from %s", data)
+		}
+		if r := resumed.ResultAsOf(time.Unix(50, 0)); r.NumVoters() != 5 {
+			t.Errorf("%d voters before the timestamped ballot instead of 5", r.NumVoters())
+		}
+		if !resumed.Accept(id) || !e.Accept(id) || !resumed.Result().Equal(e.Result()) {
+			t.Error("provisional ballot not resumed")
+		}
+	}
+
+	var e condorcet.Election
+	for _, data := range []string{
+		`{"version":2,"candidates":3}`,
+		`{"version":1,"candidates":1}`,
+		`{"version":1,"candidates":2,"voters":1,"ballots":1,"pairwise":[[0,1],[1,0]]}`,
+		`{"version":1,"candidates":2,"voters":1,"ballots":1,"pairwise":[[0,1]]}`,
+		`{"version":1,"candidates":2,"voters":1,"ballots":1}`,
+	} {
+		if err := json.Unmarshal([]byte(data), &e); err == nil {
+			t.Errorf("invalid election %s accepted", data)
+		}
+	}
+}
+
+// TestElection_MarshalJSON_exact asserts that an exact election without vote stays exact once resumed.
+func TestElection_MarshalJSON_exact(t *testing.T) {
+	e, _ := condorcet.New(3, condorcet.Exact())
+	for name, codec := range map[string]struct {
+		marshal   func() ([]byte, error)
+		unmarshal func(*condorcet.Election, []byte) error
+	}{
+		"json":   {e.MarshalJSON, (*condorcet.Election).UnmarshalJSON},
+		"binary": {e.MarshalBinary, (*condorcet.Election).UnmarshalBinary},
+		"proto":  {e.MarshalProto, (*condorcet.Election).UnmarshalProto},
+	} {
+		data, err := codec.marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var resumed condorcet.Election
+		if err := codec.unmarshal(&resumed, data); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		resumed.Vote(2, 0, 1)
+		if resumed.Result().Profile() == nil {
+			t.Errorf("%s: resumed election is not exact", name)
+		}
+	}
+}
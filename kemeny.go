@@ -0,0 +1,98 @@
+package condorcet
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultKemenyLimit is the default maximum number of candidates
+// of the Kemeny-Young method (see KemenyLimit).
+const DefaultKemenyLimit = 10
+
+// Kemeny returns the outcome of the Kemeny-Young method:
+// the ranking maximizing the number of pairwise preferences of the voters it agrees with,
+// and this number, its score.
+// If several rankings are optimal, one of them is chosen deterministically.
+//
+// The exact search runs in O(2^n * n^2) time and O(2^n) memory for n candidates,
+// so it fails above the limit set by KemenyLimit.
+// Candidates excluded by the eligibility hook are not ranked,
+// nor is "none of the above": the ranking orders the real candidates (see NOTAWins).
+func (r Result) Kemeny() (order []int, score int, err error) {
+	return r.KemenyCtx(context.Background())
+}
+
+// KemenyCtx is like Kemeny, but abandons the search when ctx is done,
+// e.g. to impose a timeout, and then returns the error of ctx.
+func (r Result) KemenyCtx(ctx context.Context) (order []int, score int, err error) {
+	candidates := r.withoutNOTA(r.e.candidates())
+	limit := r.e.kemenyLimit
+	if limit <= 0 {
+		limit = DefaultKemenyLimit
+	}
+	if len(candidates) > limit {
+		return nil, 0, fmt.Errorf("%d candidates exceed the Kemeny-Young limit of %d", len(candidates), limit)
+	}
+
+	r.e.do(PhaseKemeny, func() { order, score = r.kemeny(candidates, stopper(ctx)) })
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	return order, score, nil
+}
+
+// kemeny searches the optimal ranking of the candidates by dynamic programming
+// over the sets of candidates placed at the top of the ranking.
+// If stop is not nil, it is called regularly and the search is abandoned if it returns true.
+func (r Result) kemeny(candidates []int, stop func() bool) (order []int, score int) {
+	n := len(candidates)
+	full := 1<<uint(n) - 1
+
+	// best[s] is the best score of the candidates of s placed at the top,
+	// counting the pairs within s; last[s] is the last of them in the best order
+	best := make([]int, full+1)
+	last := make([]int, full+1)
+	for s := 1; s <= full; s++ {
+		if stop != nil && s&0xfff == 0 && stop() {
+			return nil, 0
+		}
+
+		best[s] = -1
+		for k := n - 1; k >= 0; k-- {
+			if s&(1<<uint(k)) == 0 {
+				continue
+			}
+			// candidate k is placed last among s: the others of s are preferred to it
+			rest := s &^ (1 << uint(k))
+			v := best[rest]
+			for j := 0; j < n; j++ {
+				if rest&(1<<uint(j)) != 0 {
+					v += r.e.m.at(candidates[j], candidates[k])
+				}
+			}
+			if v >= best[s] {
+				best[s], last[s] = v, k
+			}
+		}
+	}
+
+	order = make([]int, n)
+	for s, p := full, n-1; s != 0; p-- {
+		order[p] = candidates[last[s]]
+		s &^= 1 << uint(last[s])
+	}
+	return order, best[full]
+}
+
+func init() {
+	completions = append(completions, completion{
+		name: "Kemeny-Young",
+		winners: func(r Result) []int {
+			order, _, err := r.Kemeny()
+			if err != nil || len(order) == 0 {
+				return nil
+			}
+			return order[:1]
+		},
+	})
+}
@@ -0,0 +1,54 @@
+package condorcet_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_Kemeny checks the Kemeny-Young method on the testcases.
+func TestResult_Kemeny(t *testing.T) {
+	testcases := []struct {
+		label string
+		order []int
+		score int
+	}{
+		// example of https://en.wikipedia.org/wiki/Kemeny%E2%80%93Young_method
+		{"4 candidates", []int{3, 0, 1, 2}, 393},
+		{"Condorcet's example", []int{2, 1, 0}, 113},
+		{"paradoxe", []int{1, 2, 0}, 104},
+	}
+
+	for _, tc := range testcases {
+		order, score, err := result(t, tc.label).Kemeny()
+		if err != nil {
+			t.Fatalf("%s: %v", tc.label, err)
+		}
+		if !reflect.DeepEqual(order, tc.order) || score != tc.score {
+			t.Errorf("%s: (%v, %d) instead of (%v, %d)", tc.label, order, score, tc.order, tc.score)
+		}
+	}
+
+	e, _ := condorcet.New(5, condorcet.KemenyLimit(4))
+	if _, _, err := e.Result().Kemeny(); err == nil {
+		t.Error("Kemeny-Young method computed above the limit")
+	}
+}
+
+// TestResult_KemenyCtx checks that the search is abandoned when the context is done.
+func TestResult_KemenyCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	e, _ := condorcet.New(14, condorcet.KemenyLimit(14))
+	e.Vote(13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0)
+	if _, _, err := e.Result().KemenyCtx(ctx); err != context.Canceled {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	order, _, err := result(t, "paradoxe").KemenyCtx(context.Background())
+	if err != nil || !reflect.DeepEqual(order, []int{1, 2, 0}) {
+		t.Errorf("order is %v (%v)", order, err)
+	}
+}
@@ -0,0 +1,114 @@
+package condorcet
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Keyed is an election whose candidates are identified by arbitrary comparable keys,
+// e.g. strings, UUIDs or structs, instead of indexes.
+// It maps the keys to the indexes of an underlying Election.
+//
+// The package supports Go versions without type parameters,
+// so keys are passed as interface{} values: a key must have the same dynamic type
+// and value as the one given to NewKeyed.
+type Keyed struct {
+	e     *Election
+	keys  []interface{}
+	index map[interface{}]int
+}
+
+// NewKeyed returns an election with one candidate per key.
+// Keys must be comparable and distinct.
+func NewKeyed(keys []interface{}, opts ...Option) (*Keyed, error) {
+	index := make(map[interface{}]int, len(keys))
+	for c, key := range keys {
+		if key == nil || !reflect.TypeOf(key).Comparable() {
+			return nil, errors.New("expecting comparable candidate keys")
+		}
+		if _, ok := index[key]; ok {
+			return nil, errors.New("expecting distinct candidate keys")
+		}
+		index[key] = c
+	}
+
+	e, err := New(len(keys), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Keyed{e: e, keys: append([]interface{}(nil), keys...), index: index}, nil
+}
+
+// Election returns the underlying election, whose candidate i has key keys[i].
+func (k *Keyed) Election() *Election { return k.e }
+
+// Vote registers the ballot like Election.Vote.
+// It returns false if a key is unknown.
+func (k *Keyed) Vote(ballot ...interface{}) bool {
+	indexes, ok := k.indexes(ballot)
+	return ok && k.e.Vote(indexes...)
+}
+
+// indexes returns the indexes of the keys.
+func (k *Keyed) indexes(keys []interface{}) ([]int, bool) {
+	indexes := make([]int, len(keys))
+	for i, key := range keys {
+		c, ok := k.lookup(key)
+		if !ok {
+			return nil, false
+		}
+		indexes[i] = c
+	}
+	return indexes, true
+}
+
+// lookup returns the index of the key.
+func (k *Keyed) lookup(key interface{}) (int, bool) {
+	if key == nil || !reflect.TypeOf(key).Comparable() {
+		return 0, false
+	}
+	c, ok := k.index[key]
+	return c, ok
+}
+
+// Result returns a snapshot of the election (see Election.Result).
+func (k *Keyed) Result() KeyedResult { return KeyedResult{k, k.e.Result()} }
+
+// KeyedResult is an immutable snapshot of a keyed election.
+type KeyedResult struct {
+	k *Keyed
+	r Result
+}
+
+// Result returns the underlying result, whose candidates are indexes.
+func (r KeyedResult) Result() Result { return r.r }
+
+// Key returns the key of the candidate with the given index.
+// "None of the above" has a nil key (see NoneOfTheAbove).
+func (r KeyedResult) Key(candidate int) interface{} {
+	if candidate < 0 || candidate >= len(r.k.keys) {
+		return nil
+	}
+	return r.k.keys[candidate]
+}
+
+// Winner returns the key of the winner, if any (see Result.Winner).
+func (r KeyedResult) Winner() (interface{}, bool) {
+	w, exist := r.r.Winner()
+	if !exist {
+		return nil, false
+	}
+	return r.Key(w), true
+}
+
+// Keys returns the ranking with candidates replaced by their keys.
+func (r KeyedResult) Keys(rk Ranking) [][]interface{} {
+	keyed := make([][]interface{}, len(rk))
+	for p, group := range rk {
+		keyed[p] = make([]interface{}, len(group))
+		for i, c := range group {
+			keyed[p][i] = r.Key(c)
+		}
+	}
+	return keyed
+}
@@ -0,0 +1,41 @@
+package condorcet_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestKeyed checks an election whose candidates are structs.
+func TestKeyed(t *testing.T) {
+	type id struct{ party, name string }
+	alice, bob, carol := id{"red", "Alice"}, id{"blue", "Bob"}, id{"red", "Carol"}
+
+	if _, err := condorcet.NewKeyed([]interface{}{alice, alice}); err == nil {
+		t.Error("duplicate keys accepted")
+	}
+	if _, err := condorcet.NewKeyed([]interface{}{alice, []int{1}}); err == nil {
+		t.Error("non-comparable key accepted")
+	}
+
+	k, err := condorcet.NewKeyed([]interface{}{alice, bob, carol})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.Vote(alice, bob, id{"green", "Dave"}) {
+		t.Error("ballot with an unknown key accepted")
+	}
+	k.Vote(carol, alice, bob)
+	k.Vote(carol, bob, alice)
+	k.Vote(alice, carol, bob)
+
+	r := k.Result()
+	if w, ok := r.Winner(); !ok || w != carol {
+		t.Errorf("winner is (%v, %v) instead of Carol", w, ok)
+	}
+	want := [][]interface{}{{carol}, {alice}, {bob}}
+	if rk := r.Keys(condorcet.Ranking{{2}, {0}, {1}}); !reflect.DeepEqual(rk, want) {
+		t.Errorf("unexpected ranking %v", rk)
+	}
+}
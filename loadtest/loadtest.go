@@ -0,0 +1,232 @@
+// Package loadtest drives concurrent Vote and Result traffic against an Election
+// and reports throughput, latency percentiles and allocation statistics.
+package loadtest
+
+import (
+	"errors"
+	"math/bits"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// Config configures a load test.
+type Config struct {
+	Candidates  int                // number of candidates of the election
+	Options     []condorcet.Option // options of the election, e.g. condorcet.Exact or condorcet.Journal
+	Workers     int                // number of concurrent goroutines
+	Rate        int                // target number of operations per second, 0 for no limit
+	Duration    time.Duration      // duration of the test
+	ResultEvery int                // each worker requests a result every ResultEvery votes, 0 for never
+	Seed        int64              // seed of the random ballots
+}
+
+// Percentiles summarizes a latency distribution.
+type Percentiles struct {
+	P50, P90, P99, Max time.Duration
+}
+
+// Report is the outcome of a load test.
+type Report struct {
+	Votes      int           // number of votes cast
+	Rejected   int           // number of votes rejected by the election, e.g. by a failing journal
+	Results    int           // number of results requested
+	Elapsed    time.Duration // actual duration of the test
+	Throughput float64       // operations per second
+
+	Vote   Percentiles // latency of Vote
+	Result Percentiles // latency of Result
+
+	// Allocations of the election per operation.
+	// The harness records latencies and generates ballots without allocating.
+	Allocs     uint64 // number of heap allocations per operation
+	AllocBytes uint64 // allocated bytes per operation
+}
+
+// Run runs a load test.
+//
+// The Election is not safe for concurrent use,
+// so operations are serialized by a mutex as an embedding service would do:
+// latencies include the time spent waiting for the lock.
+// Latency percentiles are overestimated by less than 1/32.
+func Run(cfg Config) (Report, error) {
+	if cfg.Workers < 1 {
+		return Report{}, errors.New("expecting at least 1 worker")
+	}
+	if cfg.Duration <= 0 {
+		return Report{}, errors.New("expecting a positive duration")
+	}
+	if cfg.Rate < 0 || cfg.Rate > int(time.Second) {
+		return Report{}, errors.New("expecting a rate between 0 and 1e9 operations per second")
+	}
+	e, err := condorcet.New(cfg.Candidates, cfg.Options...)
+	if err != nil {
+		return Report{}, err
+	}
+	n := e.Result().NumCandidates() // including "none of the above"
+
+	// rate limiting: one token per operation
+	var tokens <-chan time.Time
+	if cfg.Rate > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(cfg.Rate))
+		defer ticker.Stop()
+		tokens = ticker.C
+	}
+
+	var (
+		mu       sync.Mutex // protects the election
+		wg       sync.WaitGroup
+		votes    = make([]histogram, cfg.Workers)
+		results  = make([]histogram, cfg.Workers)
+		rejected = make([]int, cfg.Workers)
+		ballots  = make([][]int, cfg.Workers)
+		before   runtime.MemStats
+		after    runtime.MemStats
+		deadline = time.Now().Add(cfg.Duration)
+	)
+
+	rnds := make([]*rand.Rand, cfg.Workers)
+	for w := range rnds {
+		rnds[w] = rand.New(rand.NewSource(cfg.Seed + int64(w)))
+		ballots[w] = rnds[w].Perm(n)
+	}
+
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for w := 0; w < cfg.Workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			rnd, ballot := rnds[w], ballots[w]
+			swap := func(i, j int) { ballot[i], ballot[j] = ballot[j], ballot[i] }
+
+			for time.Now().Before(deadline) {
+				if tokens != nil {
+					<-tokens
+				}
+				rnd.Shuffle(len(ballot), swap)
+
+				t := time.Now()
+				mu.Lock()
+				ok := e.Vote(ballot...)
+				mu.Unlock()
+				votes[w].record(time.Since(t))
+				if !ok {
+					rejected[w]++
+				}
+
+				if cfg.ResultEvery > 0 && votes[w].n%cfg.ResultEvery == 0 {
+					t := time.Now()
+					mu.Lock()
+					r := e.Result()
+					mu.Unlock()
+					r.Winner()
+					results[w].record(time.Since(t))
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	rep := Report{
+		Elapsed: elapsed,
+		Vote:    percentiles(votes),
+		Result:  percentiles(results),
+	}
+	for w := range votes {
+		rep.Votes += votes[w].n
+		rep.Results += results[w].n
+		rep.Rejected += rejected[w]
+	}
+	if ops := rep.Votes + rep.Results; ops > 0 {
+		rep.Throughput = float64(ops) / elapsed.Seconds()
+		rep.Allocs = (after.Mallocs - before.Mallocs) / uint64(ops)
+		rep.AllocBytes = (after.TotalAlloc - before.TotalAlloc) / uint64(ops)
+	}
+	return rep, nil
+}
+
+// histogram is a latency distribution with a relative precision of 1/32:
+// it records latencies without allocating.
+//
+// Latencies below 64ns have their own bucket.
+// Above, each power of two is split in 32 buckets.
+type histogram struct {
+	counts [64 + 58*32]int
+	n      int
+	max    time.Duration
+}
+
+// bucket returns the bucket of the latency.
+func bucket(d time.Duration) int {
+	v := uint64(d)
+	if d < 0 {
+		v = 0
+	}
+	if v < 64 {
+		return int(v)
+	}
+	shift := bits.Len64(v) - 6 // v>>shift is in [32, 64)
+	return 64 + (shift-1)*32 + int(v>>uint(shift)) - 32
+}
+
+// upper returns the upper bound of the bucket.
+func upper(b int) time.Duration {
+	if b < 64 {
+		return time.Duration(b)
+	}
+	shift := uint((b-64)/32 + 1)
+	mantissa := uint64((b-64)%32 + 32)
+	return time.Duration((mantissa+1)<<shift - 1)
+}
+
+// record adds a latency to the histogram.
+func (h *histogram) record(d time.Duration) {
+	h.counts[bucket(d)]++
+	h.n++
+	if d > h.max {
+		h.max = d
+	}
+}
+
+// percentiles merges and summarizes the latencies measured by the workers.
+func percentiles(latencies []histogram) Percentiles {
+	var all histogram
+	for k := range latencies {
+		for b, count := range latencies[k].counts {
+			all.counts[b] += count
+		}
+		all.n += latencies[k].n
+		if latencies[k].max > all.max {
+			all.max = latencies[k].max
+		}
+	}
+	if all.n == 0 {
+		return Percentiles{}
+	}
+
+	at := func(p float64) time.Duration {
+		rank := int(p * float64(all.n-1)) // 0-based rank of the percentile
+		for b, count := range all.counts {
+			if rank < count {
+				if u := upper(b); u < all.max {
+					return u
+				}
+				return all.max
+			}
+			rank -= count
+		}
+		return all.max
+	}
+	return Percentiles{
+		P50: at(0.50),
+		P90: at(0.90),
+		P99: at(0.99),
+		Max: all.max,
+	}
+}
@@ -0,0 +1,105 @@
+package loadtest_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/loadtest"
+)
+
+// TestRun runs a short load test and checks the report is consistent.
+func TestRun(t *testing.T) {
+	rep, err := loadtest.Run(loadtest.Config{
+		Candidates:  5,
+		Workers:     4,
+		Duration:    50 * time.Millisecond,
+		ResultEvery: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.Votes == 0 || rep.Results == 0 {
+		t.Errorf("no traffic: %+v", rep)
+	}
+	if rep.Vote.P50 > rep.Vote.P99 || rep.Vote.P99 > rep.Vote.Max {
+		t.Errorf("inconsistent percentiles: %+v", rep.Vote)
+	}
+
+	if _, err := loadtest.Run(loadtest.Config{Candidates: 5}); err == nil {
+		t.Error("load test without workers accepted")
+	}
+	for _, rate := range []int{-1, int(time.Second) + 1} {
+		cfg := loadtest.Config{Candidates: 5, Workers: 1, Duration: time.Millisecond, Rate: rate}
+		if _, err := loadtest.Run(cfg); err == nil {
+			t.Errorf("rate %d accepted", rate)
+		}
+	}
+}
+
+// TestRun_allocs checks that the harness does not count its own allocations.
+func TestRun_allocs(t *testing.T) {
+	rep, err := loadtest.Run(loadtest.Config{
+		Candidates: 5,
+		Workers:    2,
+		Duration:   50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// voting does not allocate
+	if rep.Allocs != 0 {
+		t.Errorf("%d allocs/op instead of 0 for %d votes", rep.Allocs, rep.Votes)
+	}
+}
+
+// TestRun_options checks that the options configure the election under test.
+func TestRun_options(t *testing.T) {
+	var journal bytes.Buffer
+	rep, err := loadtest.Run(loadtest.Config{
+		Candidates:  4,
+		Options:     []condorcet.Option{condorcet.Exact(), condorcet.NoneOfTheAbove(), condorcet.Journal(&journal)},
+		Workers:     2,
+		Duration:    20 * time.Millisecond,
+		ResultEvery: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.Votes == 0 || rep.Rejected != 0 {
+		t.Errorf("%d votes rejected out of %d", rep.Rejected, rep.Votes)
+	}
+	if lines := bytes.Count(journal.Bytes(), []byte("\n")); lines != rep.Votes {
+		t.Errorf("%d journal entries for %d votes", lines, rep.Votes)
+	}
+
+	rep, err = loadtest.Run(loadtest.Config{
+		Candidates: 4,
+		Options:    []condorcet.Option{condorcet.Journal(failingWriter{})},
+		Workers:    1,
+		Duration:   time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.Rejected != rep.Votes {
+		t.Errorf("%d votes rejected out of %d by a failing journal", rep.Rejected, rep.Votes)
+	}
+
+	cfg := loadtest.Config{
+		Candidates: 4,
+		Options:    []condorcet.Option{condorcet.Exact(), condorcet.AllowTruncation()},
+		Workers:    1,
+		Duration:   time.Millisecond,
+	}
+	if _, err := loadtest.Run(cfg); err == nil {
+		t.Error("invalid options accepted")
+	}
+}
+
+// failingWriter is a writer which always fails.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
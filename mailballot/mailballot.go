@@ -0,0 +1,157 @@
+// Package mailballot extracts ranked ballots from email messages,
+// in the spirit of the Devotee voting system used by mailing-list based organizations.
+//
+// The body of a ballot message contains one line per candidate,
+// in the order of the election, with the rank given in brackets:
+//
+//	[ 2 ] Alice
+//	[ 1 ] Bob
+//	[ 3 ] Carol
+//
+// Other lines are ignored.
+package mailballot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// Verifier checks the signature of a message and returns the identity of the voter.
+type Verifier func(header mail.Header, body []byte) (voter string, err error)
+
+// rankLine matches a line of the ballot: the rank in brackets followed by the candidate.
+var rankLine = regexp.MustCompile(`^\s*\[\s*(\d*)\s*\]\s*(.*?)\s*$`)
+
+// Parser parses ballot messages.
+type Parser struct {
+	// Candidates are the names of the candidates, in the order of the election.
+	Candidates []string
+
+	// Verify checks the signature of the message.
+	// If nil, signatures are not checked and the voter is the address of the sender.
+	Verify Verifier
+}
+
+// Parse reads a message and returns the voter and its ballot.
+func (p Parser) Parse(r io.Reader) (voter string, ballot []int, err error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return "", nil, err
+	}
+	body, err := ioutil.ReadAll(msg.Body)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if p.Verify != nil {
+		voter, err = p.Verify(msg.Header, body)
+		if err != nil {
+			return "", nil, fmt.Errorf("signature verification failed: %v", err)
+		}
+	} else {
+		from, err := mail.ParseAddress(msg.Header.Get("From"))
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid sender: %v", err)
+		}
+		voter = from.Address
+	}
+
+	ballot, err = p.ParseBody(string(body))
+	if err != nil {
+		return "", nil, err
+	}
+	return voter, ballot, nil
+}
+
+// ParseBody extracts the ballot from the body of a message.
+func (p Parser) ParseBody(body string) ([]int, error) {
+	ranks := make([]int, 0, len(p.Candidates))
+	for _, line := range strings.Split(body, "\n") {
+		match := rankLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		c := len(ranks)
+		if c >= len(p.Candidates) {
+			return nil, errors.New("too many ranked lines")
+		}
+		if !strings.EqualFold(match[2], strings.TrimSpace(p.Candidates[c])) {
+			return nil, fmt.Errorf("expecting candidate %q, found %q", p.Candidates[c], match[2])
+		}
+		if match[1] == "" {
+			return nil, fmt.Errorf("candidate %q is not ranked", p.Candidates[c])
+		}
+		rank, err := strconv.Atoi(match[1])
+		if err != nil || rank < 1 || rank > len(p.Candidates) {
+			return nil, fmt.Errorf("invalid rank %q for candidate %q", match[1], p.Candidates[c])
+		}
+		ranks = append(ranks, rank)
+	}
+	if len(ranks) != len(p.Candidates) {
+		return nil, fmt.Errorf("expecting %d ranked lines, found %d", len(p.Candidates), len(ranks))
+	}
+
+	// ranks to ballot
+	ballot := make([]int, len(ranks))
+	for i := range ballot {
+		ballot[i] = -1
+	}
+	for c, rank := range ranks {
+		if ballot[rank-1] >= 0 {
+			return nil, fmt.Errorf("rank %d is given twice", rank)
+		}
+		ballot[rank-1] = c
+	}
+	return ballot, nil
+}
+
+// Box tallies ballot messages into an election.
+// Each voter votes once.
+// It is safe for concurrent use.
+type Box struct {
+	Parser Parser
+
+	mu       sync.Mutex
+	election *condorcet.Election
+	voted    map[string]bool
+}
+
+// NewBox returns a box tallying messages parsed by p into e.
+// The election must have as many candidates as the parser.
+func NewBox(p Parser, e *condorcet.Election) *Box {
+	return &Box{
+		Parser:   p,
+		election: e,
+		voted:    make(map[string]bool),
+	}
+}
+
+// Receive parses a message and tallies its ballot.
+// It returns the voter.
+func (b *Box) Receive(r io.Reader) (string, error) {
+	voter, ballot, err := b.Parser.Parse(r)
+	if err != nil {
+		return "", err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.voted[voter] {
+		return voter, fmt.Errorf("%s already voted", voter)
+	}
+	if !b.election.Vote(ballot...) {
+		return voter, errors.New("ballot does not match the election")
+	}
+	b.voted[voter] = true
+	return voter, nil
+}
@@ -0,0 +1,72 @@
+package mailballot_test
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/mailballot"
+)
+
+const message = `From: Jane <jane@example.org>
+To: vote@example.org
+Subject: ballot
+
+Please find my ballot below.
+
+[ 2 ] Alice
+[ 1 ] Bob
+[ 3 ] Carol
+`
+
+// TestBox_Receive asserts that a message is parsed and tallied once.
+func TestBox_Receive(t *testing.T) {
+	e, _ := condorcet.New(3)
+	b := mailballot.NewBox(mailballot.Parser{Candidates: []string{"Alice", "Bob", "Carol"}}, e)
+
+	voter, err := b.Receive(strings.NewReader(message))
+	if err != nil {
+		t.Fatalf("valid message rejected: %v", err)
+	}
+	if voter != "jane@example.org" {
+		t.Errorf("unexpected voter %q", voter)
+	}
+	if _, err := b.Receive(strings.NewReader(message)); err == nil {
+		t.Error("second ballot of the same voter accepted")
+	}
+
+	if w, exist := e.Result().Winner(); !exist || w != 1 {
+		t.Errorf("unexpected winner (%d, %v)", w, exist)
+	}
+}
+
+// TestParser_Verify asserts that the verification hook is called.
+func TestParser_Verify(t *testing.T) {
+	p := mailballot.Parser{
+		Candidates: []string{"Alice", "Bob", "Carol"},
+		Verify: func(mail.Header, []byte) (string, error) {
+			return "", errors.New("bad signature")
+		},
+	}
+	if _, _, err := p.Parse(strings.NewReader(message)); err == nil {
+		t.Error("unverified message accepted")
+	}
+}
+
+// TestParser_ParseBody_invalid asserts that invalid bodies are rejected.
+func TestParser_ParseBody_invalid(t *testing.T) {
+	p := mailballot.Parser{Candidates: []string{"A", "B"}}
+	for _, body := range []string{
+		"[ 1 ] A\n[ 1 ] B\n", // duplicate rank
+		"[ 1 ] A\n[   ] B\n", // unranked
+		"[ 1 ] B\n[ 2 ] A\n", // wrong order
+		"[ 1 ] A\n",          // missing line
+		"[ 1 ] A\n[ 3 ] B\n", // rank out of range
+	} {
+		if _, err := p.ParseBody(body); err == nil {
+			t.Errorf("invalid body accepted: %q", body)
+		}
+	}
+}
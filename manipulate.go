@@ -0,0 +1,143 @@
+package condorcet
+
+import "sort"
+
+// Manipulation is a strategic vote changing the winner of a method (see Result.Manipulate).
+type Manipulation struct {
+	Winner    int   // winner of the sincere ballots
+	Candidate int   // candidate elected by the manipulation
+	Coalition int   // number of voters of the coalition
+	Ballot    []int // insincere ballot cast by the voters of the coalition
+}
+
+// Manipulate searches for a coalition of voters who could elect another candidate
+// by misreporting their preferences, with the given method (see Methods):
+// the voters of the coalition all prefer the new winner to the winner of the sincere ballots,
+// so that they all gain from the manipulation.
+// It returns the smallest coalition found, and false if none is found
+// or if the method has no unique winner.
+//
+// The search is a heuristic: a manipulation may exist even if none is found.
+// For each candidate, the coalition casts the ballot ranking it first,
+// the winner last and the other candidates from the weakest to the strongest (see Copeland),
+// and the voters whose sincere ballots rank the candidate the lowest join the coalition first.
+// A Condorcet winner cannot be replaced by such a coalition:
+// the voters preferring the candidate to the winner already support it in their contest.
+//
+// It requires the full profile of the election (see Exact).
+func (r Result) Manipulate(method Method) (Manipulation, bool, error) {
+	if r.e.p == nil {
+		return Manipulation{}, false, ErrNoProfile
+	}
+	w, unique, err := method(r)
+	if err != nil || !unique {
+		return Manipulation{}, false, err
+	}
+
+	_, _, scores := r.Copeland()
+	weakest := r.e.candidates()
+	sort.SliceStable(weakest, func(a, b int) bool { return scores[weakest[a]] < scores[weakest[b]] })
+
+	var (
+		best  Manipulation
+		found bool
+	)
+	for _, c := range r.e.candidates() {
+		if c == w {
+			continue
+		}
+
+		// insincere ballot
+		ballot := []int{c}
+		for _, x := range weakest {
+			if x != c && x != w {
+				ballot = append(ballot, x)
+			}
+		}
+		for x := 0; x < r.e.num(); x++ {
+			if !r.e.eligible(x) {
+				ballot = append(ballot, x) // excluded candidates do not matter
+			}
+		}
+		ballot = append(ballot, w)
+
+		// coalition, by order of joining
+		coalition, size := r.coalition(c, w)
+		elects := func(k int) (bool, error) {
+			v, unique, err := method(r.manipulated(coalition, k, ballot))
+			return unique && v == c, err
+		}
+		if ok, err := elects(size); err != nil || !ok {
+			if err != nil {
+				return Manipulation{}, false, err
+			}
+			continue
+		}
+
+		// smallest coalition, assuming that larger coalitions also succeed
+		lo, hi := 0, size
+		for lo+1 < hi {
+			mid := (lo + hi) / 2
+			ok, err := elects(mid)
+			if err != nil {
+				return Manipulation{}, false, err
+			}
+			if ok {
+				hi = mid
+			} else {
+				lo = mid
+			}
+		}
+		if !found || hi < best.Coalition {
+			best, found = Manipulation{w, c, hi, ballot}, true
+		}
+	}
+	return best, found, nil
+}
+
+// coalitionBallot is a sincere ballot of a coalition.
+type coalitionBallot struct {
+	code  uint64 // Lehmer code of the ballot
+	count int    // number of voters casting the ballot
+	above int    // number of candidates ranked above the candidate of the coalition
+}
+
+// coalition returns the sincere ballots of the voters preferring c to w,
+// the ones ranking c the lowest first, and the number of these voters.
+func (r Result) coalition(c, w int) ([]coalitionBallot, int) {
+	var (
+		ballots []coalitionBallot
+		size    int
+	)
+	for code, count := range r.e.p {
+		if count == 0 {
+			continue
+		}
+		ballot, _ := DecodeBallot(uint64(code), r.e.num())
+		pos := positions(ballot)
+		if pos[c] < pos[w] {
+			ballots = append(ballots, coalitionBallot{uint64(code), count, pos[c]})
+			size += count
+		}
+	}
+	sort.SliceStable(ballots, func(a, b int) bool { return ballots[a].above > ballots[b].above })
+	return ballots, size
+}
+
+// manipulated returns the result where the first k voters of the coalition cast the ballot.
+func (r Result) manipulated(coalition []coalitionBallot, k int, ballot []int) Result {
+	cp := r.e.clone()
+	code := encode(ballot)
+	for _, b := range coalition {
+		if k == 0 {
+			break
+		}
+		n := min(k, b.count)
+		cp.p[b.code] -= n
+		cp.p[code] += n
+		k -= n
+	}
+	cp.dirty = true
+	cp.sync()
+	return Result{cp}
+}
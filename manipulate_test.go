@@ -0,0 +1,34 @@
+package condorcet_test
+
+import (
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_Manipulate checks the coalition found in a cycle.
+func TestResult_Manipulate(t *testing.T) {
+	schulze := condorcet.Methods(false)["Schulze"]
+	if _, _, err := result(t, "paradoxe").Manipulate(schulze); err != condorcet.ErrNoProfile {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	// a Condorcet winner cannot be replaced
+	if m, found, err := result(t, "Condorcet's example", condorcet.Exact()).Manipulate(schulze); err != nil || found {
+		t.Errorf("manipulation %+v of a Condorcet winner: %v", m, err)
+	}
+
+	// 0 beats 1 by 3, 1 beats 2 by 5 and 2 beats 0 by 1: 0 wins
+	// 3 voters ranking 1 before 2 make 2 the Condorcet winner
+	e, _ := condorcet.New(3, condorcet.Exact())
+	e.VoteN(4, 0, 1, 2)
+	e.VoteN(3, 1, 2, 0)
+	e.VoteN(2, 2, 0, 1)
+	m, found, err := e.Result().Manipulate(schulze)
+	if err != nil || !found {
+		t.Fatalf("no manipulation found: %v", err)
+	}
+	if m.Winner != 0 || m.Candidate != 2 || m.Coalition != 3 || condorcet.Ballot(m.Ballot).String() != "2 > 1 > 0" {
+		t.Errorf("unexpected manipulation %+v", m)
+	}
+}
@@ -0,0 +1,67 @@
+package condorcet
+
+import (
+	"errors"
+	"sort"
+)
+
+// Matchup details the head-to-head contest between two candidates.
+// It is designed to be exported as JSON.
+type Matchup struct {
+	A             int `json:"a"`
+	B             int `json:"b"`
+	ForA          int `json:"for_a"`         // voters preferring A to B
+	ForB          int `json:"for_b"`         // voters preferring B to A
+	Margin        int `json:"margin"`        // ForA - ForB
+	Participation int `json:"participation"` // voters expressing a preference between A and B
+
+	// Ballots preferring each candidate, by decreasing count.
+	// They are only available if the election stores its full profile (see Exact).
+	BallotsForA []BallotCount `json:"ballots_for_a,omitempty"`
+	BallotsForB []BallotCount `json:"ballots_for_b,omitempty"`
+}
+
+// BallotCount is a ballot and the number of voters who cast it.
+type BallotCount struct {
+	Ballot []int `json:"ballot"`
+	Count  int   `json:"count"`
+}
+
+// Matchup returns the detail of the contest between candidates a and b.
+func (r Result) Matchup(a, b int) (Matchup, error) {
+	if a < 0 || a >= r.e.num() || b < 0 || b >= r.e.num() {
+		return Matchup{}, errors.New("candidate out of range")
+	}
+	if a == b {
+		return Matchup{}, errors.New("a candidate has no contest against itself")
+	}
+
+	m := Matchup{
+		A:    a,
+		B:    b,
+		ForA: r.e.m.at(a, b),
+		ForB: r.e.m.at(b, a),
+	}
+	m.Margin = m.ForA - m.ForB
+	m.Participation = m.ForA + m.ForB
+
+	for code, count := range r.e.p {
+		if count == 0 {
+			continue
+		}
+		ballot, _ := DecodeBallot(uint64(code), r.e.num())
+		pos := positions(ballot)
+		if pos[a] < pos[b] {
+			m.BallotsForA = append(m.BallotsForA, BallotCount{ballot, count})
+		} else {
+			m.BallotsForB = append(m.BallotsForB, BallotCount{ballot, count})
+		}
+	}
+	byCount := func(bc []BallotCount) func(i, j int) bool {
+		return func(i, j int) bool { return bc[i].Count > bc[j].Count }
+	}
+	sort.SliceStable(m.BallotsForA, byCount(m.BallotsForA))
+	sort.SliceStable(m.BallotsForB, byCount(m.BallotsForB))
+
+	return m, nil
+}
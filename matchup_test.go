@@ -0,0 +1,37 @@
+package condorcet_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_Matchup checks the drill-down of a contest with the full profile.
+func TestResult_Matchup(t *testing.T) {
+	e, _ := condorcet.New(3, condorcet.Exact())
+	for k := 0; k < 3; k++ {
+		e.Vote(0, 1, 2)
+	}
+	e.Vote(2, 0, 1)
+	e.Vote(1, 2, 0)
+
+	m, err := e.Result().Matchup(0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.ForA != 3 || m.ForB != 2 || m.Margin != 1 || m.Participation != 5 {
+		t.Errorf("unexpected counts: %+v", m)
+	}
+	if len(m.BallotsForA) != 1 || m.BallotsForA[0].Count != 3 || len(m.BallotsForB) != 2 {
+		t.Errorf("unexpected ballots: %+v", m)
+	}
+
+	if _, err := json.Marshal(m); err != nil {
+		t.Errorf("cannot export matchup: %v", err)
+	}
+
+	if _, err := e.Result().Matchup(1, 1); err == nil {
+		t.Error("matchup of a candidate against itself did not fail")
+	}
+}
@@ -0,0 +1,103 @@
+package condorcet
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Merge adds the ballots of other to the election,
+// e.g. to combine the tallies of precincts or shards counted independently.
+// Provisional and timestamped ballots of other are added too.
+// The merged ballots cannot be written to the journal:
+// it returns ErrJournaled if the election has one (see Journal).
+//
+// Both elections must have the same candidates, with the same names if both are named,
+// and agree on the options shaping the tally:
+// an election storing its profile (see Exact) can only merge another one storing it,
+// and one refusing truncated ballots cannot merge one allowing them (see AllowTruncation).
+func (e *Election) Merge(other *Election) error {
+	if e.journal != nil {
+		return ErrJournaled
+	}
+	if e.names != nil && other.names != nil {
+		for c := 0; c < e.num() && c < other.num(); c++ {
+			if e.Name(c) != other.Name(c) {
+				return fmt.Errorf("candidate %d is %q and %q", c, e.Name(c), other.Name(c))
+			}
+		}
+	}
+	if e.exact && !other.exact {
+		return errors.New("cannot merge an election without profile into an exact one")
+	}
+	if other.truncation && !e.truncation {
+		return errors.New("cannot merge an election allowing truncated ballots")
+	}
+
+	for _, x := range []*Election{e, other} {
+		if !x.initialized() {
+			x.init()
+		}
+		x.sync()
+	}
+	if err := e.merge(other.clone()); err != nil {
+		return err
+	}
+	e.publish()
+	return nil
+}
+
+// merge adds the ballots of o to the election.
+// It returns ErrOverflow if the merged tally would overflow.
+// Both elections must be initialized and synchronized.
+//
+// The profile is kept only if both elections store it.
+func (e *Election) merge(o *Election) (err error) {
+	e.do(PhaseMerge, func() { err = e.mergeTally(o) })
+	return err
+}
+
+// mergeTally implements merge.
+func (e *Election) mergeTally(o *Election) error {
+	if e.num() != o.num() {
+		return errors.New("elections have different numbers of candidates")
+	}
+	if e.nota != o.nota {
+		return errors.New("elections do not agree on none of the above")
+	}
+
+	if err := e.overflows(uint(o.voters)); err != nil {
+		return err
+	}
+
+	e.voters += o.voters
+	e.ballots += o.ballots
+	e.ties = e.ties || o.ties
+	if o.ties || o.truncation {
+		e.densify()
+	}
+	for i := 0; i < e.num(); i++ {
+		for j := 0; j < e.num(); j++ {
+			if i != j {
+				e.m.add(i, j, o.m.at(i, j))
+			}
+		}
+	}
+	if e.exact && o.exact {
+		for code, count := range o.p {
+			e.p[code] += count
+		}
+	} else {
+		e.exact = false
+		e.p = nil
+	}
+
+	if len(o.provisional) > 0 && e.provisional == nil {
+		e.provisional = make(map[int][]int)
+	}
+	for _, ballot := range o.provisional {
+		e.provisional[e.nextID] = ballot
+		e.nextID++
+	}
+	e.stamped = append(e.stamped, o.stamped...)
+	return nil
+}
@@ -0,0 +1,60 @@
+package condorcet_test
+
+import (
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestElection_Merge splits Condorcet's example in two precincts and merges them.
+func TestElection_Merge(t *testing.T) {
+	for _, opts := range [][]condorcet.Option{nil, {condorcet.Exact()}} {
+		north, _ := condorcet.New(3, opts...)
+		south, _ := condorcet.New(3, opts...)
+		north.VoteN(23, 0, 2, 1)
+		north.VoteN(19, 1, 2, 0)
+		south.VoteN(16, 2, 1, 0)
+		south.VoteN(2, 2, 0, 1)
+
+		if err := north.Merge(south); err != nil {
+			t.Fatal(err)
+		}
+		if north.NumVoters() != 60 || south.NumVoters() != 18 {
+			t.Errorf("%d and %d voters after merge", north.NumVoters(), south.NumVoters())
+		}
+		if w, ok := north.Result().Winner(); !ok || w != 2 {
+			t.Errorf("winner is (%d, %v) instead of 2", w, ok)
+		}
+		if err := north.Result().Verify(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	// merging an empty election, or itself
+	e, _ := condorcet.New(3)
+	empty, _ := condorcet.New(3)
+	e.Vote(0, 1, 2)
+	if err := e.Merge(empty); err != nil || e.NumVoters() != 1 {
+		t.Errorf("%d voters after merging an empty election (%v)", e.NumVoters(), err)
+	}
+	if err := e.Merge(e); err != nil || e.NumVoters() != 2 {
+		t.Errorf("%d voters after merging itself (%v)", e.NumVoters(), err)
+	}
+
+	// incompatible elections
+	four, _ := condorcet.New(4)
+	exact, _ := condorcet.New(3, condorcet.Exact())
+	truncated, _ := condorcet.New(3, condorcet.AllowTruncation())
+	named, _ := condorcet.NewNamed([]string{"a", "b", "c"})
+	renamed, _ := condorcet.NewNamed([]string{"a", "c", "b"})
+	for label, pair := range map[string][2]*condorcet.Election{
+		"candidates": {e, four},
+		"profile":    {exact, e},
+		"truncation": {e, truncated},
+		"names":      {named, renamed},
+	} {
+		if err := pair[0].Merge(pair[1]); err == nil {
+			t.Errorf("%s: incompatible elections merged", label)
+		}
+	}
+}
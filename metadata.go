@@ -0,0 +1,45 @@
+package condorcet
+
+// Metadata describes a candidate in reports and exports.
+type Metadata struct {
+	DisplayName string            `json:"display_name,omitempty"`
+	Party       string            `json:"party,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	Extra       map[string]string `json:"extra,omitempty"` // any other information
+}
+
+// Describe attaches metadata to the candidates: meta[i] describes candidate i.
+// Candidates without metadata have the zero value.
+//
+// There must not be more metadata than candidates.
+func Describe(meta ...Metadata) Option {
+	return func(e *Election) {
+		e.meta = make([]Metadata, len(meta))
+		for c, m := range meta {
+			e.meta[c] = m.clone()
+		}
+	}
+}
+
+// clone returns a deep copy of the metadata.
+func (m Metadata) clone() Metadata {
+	if m.Extra != nil {
+		extra := make(map[string]string, len(m.Extra))
+		for k, v := range m.Extra {
+			extra[k] = v
+		}
+		m.Extra = extra
+	}
+	return m
+}
+
+// described reports whether some candidate has metadata.
+func (e *Election) described() bool { return len(e.meta) > 0 }
+
+// Metadata returns the metadata of the candidate (see Describe).
+func (r Result) Metadata(candidate int) Metadata {
+	if candidate < 0 || candidate >= len(r.e.meta) {
+		return Metadata{}
+	}
+	return r.e.meta[candidate].clone()
+}
@@ -0,0 +1,56 @@
+package condorcet_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestDescribe checks that the metadata of the candidates is reported and exported.
+func TestDescribe(t *testing.T) {
+	if _, err := condorcet.New(2, condorcet.Describe(condorcet.Metadata{}, condorcet.Metadata{}, condorcet.Metadata{})); err == nil {
+		t.Error("more metadata than candidates accepted")
+	}
+
+	extra := map[string]string{"incumbent": "yes"}
+	e, err := condorcet.New(3, condorcet.Exact(), condorcet.Describe(
+		condorcet.Metadata{DisplayName: "Alice", Party: "Red", URL: "https://example.org/alice", Extra: extra},
+		condorcet.Metadata{DisplayName: "Bob"},
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	extra["incumbent"] = "no" // the election keeps its own copy
+	e.Vote(0, 1, 2)
+	r := e.Result()
+
+	if m := r.Metadata(0); m.Party != "Red" || m.Extra["incumbent"] != "yes" {
+		t.Errorf("unexpected metadata %+v", m)
+	}
+	if m := r.Metadata(2); m.DisplayName != "" {
+		t.Errorf("undescribed candidate has metadata %+v", m)
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "candidate 0: Alice (Red) https://example.org/alice\n") {
+		t.Errorf("metadata missing from the report:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := r.WriteCVRJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct{ Metadata []condorcet.Metadata }
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(doc.Metadata) != 3 || doc.Metadata[1].DisplayName != "Bob" {
+		t.Errorf("unexpected metadata %+v", doc.Metadata)
+	}
+}
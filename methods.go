@@ -0,0 +1,62 @@
+package condorcet
+
+// Method is a completion method: it returns the winner of the result,
+// false if several candidates tie, e.g. Result.Schulze.
+type Method func(r Result) (w int, unique bool, err error)
+
+// Methods returns the completion methods of the package, by name.
+// The methods requiring the ballots (see Exact) are only included if ballots is true.
+// No method elects "none of the above" (see NoneOfTheAbove).
+func Methods(ballots bool) map[string]Method {
+	methods := map[string]Method{
+		"Baldwin": func(r Result) (int, bool, error) {
+			w, unique, _ := r.Baldwin()
+			return w, unique, nil
+		},
+		"Black": func(r Result) (int, bool, error) {
+			w, unique, _ := r.Black()
+			return w, unique, nil
+		},
+		"Copeland": func(r Result) (int, bool, error) {
+			w, unique, _ := r.Copeland()
+			return w, unique, nil
+		},
+		"Minimax": func(r Result) (int, bool, error) {
+			w, unique, _ := r.Minimax()
+			return w, unique, nil
+		},
+		"River": func(r Result) (int, bool, error) {
+			w, unique, _ := r.River()
+			return w, unique, nil
+		},
+		"Schulze": func(r Result) (int, bool, error) {
+			w, unique, _ := r.Schulze()
+			return w, unique, nil
+		},
+	}
+	if ballots {
+		methods["SmithIRV"] = func(r Result) (int, bool, error) {
+			w, unique, _, err := r.SmithIRV()
+			return w, unique, err
+		}
+		methods["Tideman"] = func(r Result) (int, bool, error) {
+			w, unique, _, err := r.Tideman()
+			return w, unique, err
+		}
+	}
+	for name, m := range methods {
+		methods[name] = valid(m)
+	}
+	return methods
+}
+
+// valid returns the method reporting no unique winner if "none of the above" wins.
+func valid(m Method) Method {
+	return func(r Result) (int, bool, error) {
+		w, unique, err := m(r)
+		if err == nil && unique {
+			w, unique = r.elect([]int{w})
+		}
+		return w, unique, err
+	}
+}
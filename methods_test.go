@@ -0,0 +1,26 @@
+package condorcet_test
+
+import (
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestMethods checks that every method elects the Condorcet winner of the testcases.
+func TestMethods(t *testing.T) {
+	if len(condorcet.Methods(true)) <= len(condorcet.Methods(false)) {
+		t.Error("no method requiring the ballots")
+	}
+
+	for _, tc := range testcases {
+		if !tc.hasWinner {
+			continue
+		}
+		r := result(t, tc.label, condorcet.Exact())
+		for name, method := range condorcet.Methods(true) {
+			if w, unique, err := method(r); err != nil || !unique || w != tc.winner {
+				t.Errorf("%s: %s elects (%d, %v): %v", tc.label, name, w, unique, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package condorcet
+
+// Minimax returns the outcome of the Minimax method, in its pairwise opposition variant:
+// the maximum opposition of a candidate is the largest number of voters
+// preferring another candidate to it, and the smallest maximum opposition wins.
+//
+// It returns the winner, false if several candidates tie,
+// and the maximum opposition indexed by candidate.
+// Candidates excluded by the eligibility hook have a zero opposition and cannot win.
+// If "none of the above" has the least opposition, even tied, there is no valid winner (see NoneOfTheAbove).
+func (r Result) Minimax() (w int, unique bool, opposition []int) {
+	r.e.do(PhaseMinimax, func() { opposition = r.minimax() })
+
+	rk := r.minimaxRanking(opposition)
+	if len(rk) == 0 {
+		return 0, false, opposition
+	}
+	w, unique = r.elect(rk[0])
+	return w, unique, opposition
+}
+
+// minimax returns the maximum opposition of each candidate.
+func (r Result) minimax() []int {
+	candidates := r.e.candidates()
+	opposition := make([]int, r.e.num())
+	for _, i := range candidates {
+		for _, j := range candidates {
+			if i != j && r.e.m.at(j, i) > opposition[i] {
+				opposition[i] = r.e.m.at(j, i)
+			}
+		}
+	}
+	return opposition
+}
+
+// minimaxRanking ranks the candidates by increasing maximum opposition.
+func (r Result) minimaxRanking(opposition []int) Ranking {
+	scores := make([]int, len(opposition))
+	for c, o := range opposition {
+		scores[c] = -o
+	}
+	return rankByScore(r.e.candidates(), scores)
+}
+
+func init() {
+	completions = append(completions, completion{
+		name: "Minimax",
+		winners: func(r Result) []int {
+			_, _, opposition := r.Minimax()
+			rk := r.minimaxRanking(opposition)
+			if len(rk) == 0 {
+				return nil
+			}
+			return rk[0]
+		},
+	})
+}
@@ -0,0 +1,30 @@
+package condorcet_test
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestResult_Minimax checks the Minimax method on the testcases.
+func TestResult_Minimax(t *testing.T) {
+	testcases := []struct {
+		label      string
+		winner     int
+		unique     bool
+		opposition []int
+	}{
+		{"Condorcet's example", 2, true, []int{37, 41, 23}},
+		{"paradoxe", 1, true, []int{35, 33, 42}},
+		{"Schulze's example", 4, true, []int{25, 29, 28, 33, 24}},
+	}
+
+	for _, tc := range testcases {
+		w, unique, opposition := result(t, tc.label).Minimax()
+		if w != tc.winner || unique != tc.unique {
+			t.Errorf("%s: winner (%d, %v) instead of (%d, %v)", tc.label, w, unique, tc.winner, tc.unique)
+		}
+		if !reflect.DeepEqual(opposition, tc.opposition) {
+			t.Errorf("%s: opposition %v instead of %v", tc.label, opposition, tc.opposition)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package condorcet
+
+// MonotonicityViolation is a counterexample to the monotonicity of a method
+// (see Result.CheckMonotonicity): raising the winner on some ballots makes it lose.
+type MonotonicityViolation struct {
+	Winner int   // winner of the actual ballots
+	Ballot []int // ballot of the profile
+	Raised []int // the ballot with the winner raised
+	Count  int   // number of copies of the ballot replaced by the raised ballot
+
+	NewWinner int  // winner after the change
+	Unique    bool // false if several candidates tie after the change
+}
+
+// CheckMonotonicity searches the profile for a violation of monotonicity by the given method (see Methods):
+// whether ranking the winner higher on some identical ballots could make it lose.
+// It returns the violation changing the fewest ballots, and false if there is none
+// or if the method has no unique winner.
+// Monotonic methods, such as Schulze, never fail, while IRV hybrids may (see SmithIRV).
+//
+// Every ballot of the profile is tried, raising the winner to each higher position on some of its copies:
+// the method runs up to n times per voter for n candidates,
+// so the check is meant for elections of modest size.
+//
+// It requires the full profile of the election (see Exact).
+func (r Result) CheckMonotonicity(method Method) (MonotonicityViolation, bool, error) {
+	if r.e.p == nil {
+		return MonotonicityViolation{}, false, ErrNoProfile
+	}
+	w, unique, err := method(r)
+	if err != nil || !unique {
+		return MonotonicityViolation{}, false, err
+	}
+
+	// ballots not ranking the winner first, with the number of candidates above it
+	var (
+		ballots  []coalitionBallot
+		maxCount int
+	)
+	for code, count := range r.e.p {
+		if count == 0 {
+			continue
+		}
+		ballot, _ := DecodeBallot(uint64(code), r.e.num())
+		if p := positions(ballot)[w]; p > 0 {
+			ballots = append(ballots, coalitionBallot{uint64(code), count, p})
+			if count > maxCount {
+				maxCount = count
+			}
+		}
+	}
+
+	for k := 1; k <= maxCount; k++ {
+		for _, b := range ballots {
+			if b.count < k {
+				continue
+			}
+			ballot, _ := DecodeBallot(b.code, r.e.num())
+			for p := b.above - 1; p >= 0; p-- {
+				// the winner moves from position b.above to p
+				raised := make([]int, 0, len(ballot))
+				raised = append(raised, ballot[:p]...)
+				raised = append(raised, w)
+				raised = append(raised, ballot[p:b.above]...)
+				raised = append(raised, ballot[b.above+1:]...)
+
+				v, unique, err := method(r.manipulated([]coalitionBallot{b}, k, raised))
+				if err != nil {
+					return MonotonicityViolation{}, false, err
+				}
+				if !unique || v != w {
+					return MonotonicityViolation{w, ballot, raised, k, v, unique}, true, nil
+				}
+			}
+		}
+	}
+	return MonotonicityViolation{}, false, nil
+}
@@ -0,0 +1,45 @@
+package condorcet_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_CheckMonotonicity checks the classic failure of instant-runoff voting in a cycle.
+func TestResult_CheckMonotonicity(t *testing.T) {
+	methods := condorcet.Methods(true)
+	if _, _, err := result(t, "paradoxe").CheckMonotonicity(methods["SmithIRV"]); err != condorcet.ErrNoProfile {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	// 0 beats 1, 1 beats 2 and 2 beats 0: all are in the Smith set
+	// 2 is eliminated first and 0 wins with 65 votes against 35
+	e, _ := condorcet.New(3, condorcet.Exact())
+	e.VoteN(39, 0, 1, 2)
+	e.VoteN(35, 1, 2, 0)
+	e.VoteN(26, 2, 0, 1)
+	r := e.Result()
+
+	// raising 0 first on 10 ballots eliminates 1 first instead, and 2 wins with 51 votes
+	v, found, err := r.CheckMonotonicity(methods["SmithIRV"])
+	if err != nil || !found {
+		t.Fatalf("no violation found: %v", err)
+	}
+	want := condorcet.MonotonicityViolation{
+		Winner:    0,
+		Ballot:    []int{1, 2, 0},
+		Raised:    []int{0, 1, 2},
+		Count:     10,
+		NewWinner: 2,
+		Unique:    true,
+	}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("violation %+v instead of %+v", v, want)
+	}
+
+	if v, found, err := r.CheckMonotonicity(methods["Schulze"]); err != nil || found {
+		t.Errorf("violation %+v by the Schulze method: %v", v, err)
+	}
+}
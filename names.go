@@ -0,0 +1,85 @@
+package condorcet
+
+import (
+	"errors"
+	"strconv"
+)
+
+// NOTAName is the name of the "none of the above" candidate (see NoneOfTheAbove).
+const NOTAName = "none of the above"
+
+// NewNamed returns an election whose candidates have the given names:
+// candidate i is names[i]. Names must be distinct.
+//
+// Names are kept by the results, which can report candidates by name.
+func NewNamed(names []string, opts ...Option) (*Election, error) {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			return nil, errors.New("expecting distinct candidate names")
+		}
+		seen[name] = true
+	}
+
+	e, err := New(len(names), opts...)
+	if err != nil {
+		return nil, err
+	}
+	if e.nota && seen[NOTAName] {
+		return nil, errors.New("candidate name is reserved for none of the above")
+	}
+	e.names = append([]string(nil), names...)
+	return e, nil
+}
+
+// Name returns the name of the candidate.
+// Candidates of an election created with New, or added with AddCandidate,
+// are named after their index,
+// and "none of the above" is named NOTAName.
+func (e *Election) Name(candidate int) string {
+	if nota, ok := e.NOTA(); ok && candidate == nota {
+		return NOTAName
+	}
+	if candidate >= 0 && candidate < len(e.names) && e.names[candidate] != "" {
+		return e.names[candidate]
+	}
+	return strconv.Itoa(candidate)
+}
+
+// Index returns the index of the named candidate (see Name).
+// If there is no such candidate, it returns false.
+func (e *Election) Index(name string) (int, bool) {
+	for c := 0; c < e.num(); c++ {
+		if e.Name(c) == name {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// Name returns the name of the candidate (see Election.Name).
+func (r Result) Name(candidate int) string { return r.e.Name(candidate) }
+
+// Index returns the index of the named candidate (see Election.Index).
+func (r Result) Index(name string) (int, bool) { return r.e.Index(name) }
+
+// WinnerName returns the name of the winner, if any (see Winner).
+func (r Result) WinnerName() (string, bool) {
+	w, exist := r.Winner()
+	if !exist {
+		return "", false
+	}
+	return r.Name(w), true
+}
+
+// Names returns the ranking with candidates replaced by their names.
+func (r Result) Names(rk Ranking) [][]string {
+	named := make([][]string, len(rk))
+	for p, group := range rk {
+		named[p] = make([]string, len(group))
+		for k, c := range group {
+			named[p][k] = r.Name(c)
+		}
+	}
+	return named
+}
@@ -0,0 +1,60 @@
+package condorcet_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestNewNamed checks that results report candidates by name.
+func TestNewNamed(t *testing.T) {
+	if _, err := condorcet.NewNamed([]string{"Alice", "Bob", "Alice"}); err == nil {
+		t.Error("duplicate names accepted")
+	}
+
+	e, err := condorcet.NewNamed([]string{"Alice", "Bob", "Carol"}, condorcet.NoneOfTheAbove())
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Vote(2, 0, 1, 3)
+	e.Vote(2, 1, 0, 3)
+	r := e.Result()
+	if name, ok := r.WinnerName(); !ok || name != "Carol" {
+		t.Errorf("winner is (%q, %v) instead of Carol", name, ok)
+	}
+	if c, ok := r.Index("Bob"); !ok || c != 1 {
+		t.Errorf("Bob has index (%d, %v) instead of 1", c, ok)
+	}
+	if c, ok := r.Index(condorcet.NOTAName); !ok || c != 3 {
+		t.Errorf("none of the above has index (%d, %v) instead of 3", c, ok)
+	}
+	if _, ok := r.Index("Dave"); ok {
+		t.Error("unknown candidate found")
+	}
+	rk := condorcet.Ranking{{2}, {0, 1}, {3}}
+	want := [][]string{{"Carol"}, {"Alice", "Bob"}, {condorcet.NOTAName}}
+	if names := r.Names(rk); !reflect.DeepEqual(names, want) {
+		t.Errorf("ranking named %v instead of %v", names, want)
+	}
+
+	// names follow the candidates
+	if err := e.Remap([]int{1, 0, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.MergeCandidates(0, 2); err != nil {
+		t.Fatal(err)
+	}
+	if n := e.Name(0); n != "Bob" {
+		t.Errorf("candidate 0 is %q instead of Bob", n)
+	}
+	if n := e.Name(1); n != "Alice" {
+		t.Errorf("candidate 1 is %q instead of Alice", n)
+	}
+
+	// unnamed candidates are named after their index
+	unnamed, _ := condorcet.New(3)
+	if n := unnamed.Name(1); n != "1" {
+		t.Errorf("unnamed candidate is %q", n)
+	}
+}
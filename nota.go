@@ -0,0 +1,70 @@
+package condorcet
+
+// NOTA returns the index of the "none of the above" candidate.
+// If the election has none, it returns false.
+func (e *Election) NOTA() (int, bool) {
+	if !e.nota {
+		return 0, false
+	}
+	return e.num() - 1, true
+}
+
+// elect returns the winner of a method from the candidates sharing its first place.
+// There is no valid winner if there is no vote, and the winner is not unique if the candidates tie
+// or if "none of the above" is one of them: it returns 0 and false in both cases.
+func (r Result) elect(first []int) (w int, unique bool) {
+	if len(first) == 0 || r.NumVoters() == 0 {
+		return 0, false
+	}
+	if nota, ok := r.e.NOTA(); ok {
+		for _, c := range first {
+			if c == nota {
+				return 0, false
+			}
+		}
+	}
+	return first[0], len(first) == 1
+}
+
+// withoutNOTA returns the candidates except "none of the above".
+func (r Result) withoutNOTA(candidates []int) []int {
+	nota, ok := r.e.NOTA()
+	if !ok {
+		return candidates
+	}
+	kept := make([]int, 0, len(candidates))
+	for _, c := range candidates {
+		if c != nota {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// NOTAWins reports whether "none of the above" is the Condorcet winner,
+// in which case Winner reports no valid winner.
+func (r Result) NOTAWins() bool {
+	nota, ok := r.e.NOTA()
+	if !ok {
+		return false
+	}
+	w, exist := r.winner()
+	return exist && w == nota
+}
+
+// NOTAMatchups returns the contests of "none of the above" against each real candidate,
+// "none of the above" being the candidate A of each matchup.
+// If the election has no "none of the above" candidate, it returns nil.
+func (r Result) NOTAMatchups() []Matchup {
+	nota, ok := r.e.NOTA()
+	if !ok {
+		return nil
+	}
+
+	matchups := make([]Matchup, 0, nota)
+	for c := 0; c < nota; c++ {
+		m, _ := r.Matchup(nota, c)
+		matchups = append(matchups, m)
+	}
+	return matchups
+}
@@ -0,0 +1,108 @@
+package condorcet_test
+
+import (
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestNoneOfTheAbove checks that a winning "none of the above" is not a valid winner.
+func TestNoneOfTheAbove(t *testing.T) {
+	e, err := condorcet.New(2, condorcet.NoneOfTheAbove())
+	if err != nil {
+		t.Fatal(err)
+	}
+	nota, ok := e.NOTA()
+	if !ok || nota != 2 {
+		t.Fatalf("none of the above is (%d, %v) instead of 2", nota, ok)
+	}
+	if e.Vote(0, 1) {
+		t.Error("ballot not ranking none of the above accepted")
+	}
+
+	e.Vote(2, 0, 1)
+	e.Vote(2, 1, 0)
+	e.Vote(0, 2, 1)
+
+	r := e.Result()
+	if _, exist := r.Winner(); exist {
+		t.Error("none of the above reported as a valid winner")
+	}
+	if !r.NOTAWins() {
+		t.Error("none of the above does not win")
+	}
+
+	matchups := r.NOTAMatchups()
+	if len(matchups) != 2 {
+		t.Fatalf("%d matchups instead of 2", len(matchups))
+	}
+	if matchups[0].ForA != 2 || matchups[0].ForB != 1 {
+		t.Errorf("unexpected matchup against 0: %+v", matchups[0])
+	}
+
+	// a real candidate wins
+	e.Vote(0, 1, 2)
+	e.Vote(0, 1, 2)
+	if w, exist := e.Result().Winner(); !exist || w != 0 {
+		t.Errorf("unexpected winner (%d, %v)", w, exist)
+	}
+}
+
+// notaWins returns the result of an election won by "none of the above", with its profile.
+func notaWins(t *testing.T) condorcet.Result {
+	e, err := condorcet.New(2, condorcet.NoneOfTheAbove(), condorcet.Exact())
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Vote(2, 0, 1)
+	e.Vote(2, 1, 0)
+	e.Vote(0, 2, 1)
+	return e.Result()
+}
+
+// TestNoneOfTheAbove_methods checks that no completion method elects "none of the above".
+func TestNoneOfTheAbove_methods(t *testing.T) {
+	r := notaWins(t)
+	testcases := []struct {
+		method string
+		elect  func() (int, bool)
+	}{
+		{"Baldwin", func() (int, bool) { w, unique, _ := r.Baldwin(); return w, unique }},
+		{"Black", func() (int, bool) { w, unique, _ := r.Black(); return w, unique }},
+		{"Copeland", func() (int, bool) { w, unique, _ := r.Copeland(); return w, unique }},
+		{"Minimax", func() (int, bool) { w, unique, _ := r.Minimax(); return w, unique }},
+		{"River", func() (int, bool) { w, unique, _ := r.River(); return w, unique }},
+		{"Schulze", func() (int, bool) { w, unique, _ := r.Schulze(); return w, unique }},
+		{"SmithIRV", func() (int, bool) { w, unique, _, _ := r.SmithIRV(); return w, unique }},
+		{"Tideman", func() (int, bool) { w, unique, _, _ := r.Tideman(); return w, unique }},
+	}
+	for _, tc := range testcases {
+		if w, unique := tc.elect(); unique {
+			t.Errorf("%s elects %d", tc.method, w)
+		}
+	}
+
+	for name, method := range condorcet.Methods(true) {
+		if w, unique, err := method(r); unique || err != nil {
+			t.Errorf("method %s elects (%d, %v): %v", name, w, unique, err)
+		}
+	}
+}
+
+// TestNoneOfTheAbove_rankings checks that rankings order the real candidates only.
+func TestNoneOfTheAbove_rankings(t *testing.T) {
+	r := notaWins(t)
+
+	rk := r.Ranking()
+	if rk.Position(2) != -1 || rk.Position(0) == -1 || rk.Position(1) == -1 {
+		t.Errorf("unexpected ranking %v", rk)
+	}
+
+	order, _, err := r.Kemeny()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(order) != 2 || order[0] != 0 || order[1] != 1 {
+		t.Errorf("unexpected Kemeny-Young ranking %v", order)
+	}
+}
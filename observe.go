@@ -0,0 +1,33 @@
+package condorcet
+
+// VoteEvent describes a ballot received by the election (see Observe).
+type VoteEvent struct {
+	Ballot   []int   // candidates as received, before sanitizing
+	Groups   [][]int // groups of tied candidates of a ballot with ties (see VoteRanked), nil otherwise
+	Count    int     // number of identical ballots, or weight if Weighted
+	Weighted bool    // see VoteWeighted
+	Err      error   // why the ballot is rejected, nil if it is accepted
+}
+
+// VoteObserver is called on each ballot received by an election (see Observe).
+// It must not modify the event nor call the election.
+type VoteObserver func(VoteEvent)
+
+// Observe registers observers called on each ballot received by Vote and its variants
+// (VoteN, VoteWeighted, VoteE, VoteRanked, VoteAll and VoteAt), accepted or not,
+// e.g. for logging, metrics or live dashboards.
+// Observers are called in order, after the ballot is tallied.
+func Observe(observers ...VoteObserver) Option {
+	return func(e *Election) { e.observers = append(e.observers, observers...) }
+}
+
+// notify calls the observers with the event
+// and updates the watchers of the winner (see Watch).
+func (e *Election) notify(ev VoteEvent) {
+	for _, o := range e.observers {
+		o(ev)
+	}
+	if ev.Err == nil {
+		e.publish()
+	}
+}
@@ -0,0 +1,46 @@
+package condorcet_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestObserve counts the accepted and rejected ballots of every voting method.
+func TestObserve(t *testing.T) {
+	var events []condorcet.VoteEvent
+	e, _ := condorcet.New(3, condorcet.AllowTruncation(), condorcet.Observe(func(ev condorcet.VoteEvent) {
+		events = append(events, ev)
+	}))
+
+	e.Vote(0, 1, 2)
+	e.VoteN(3, 1, 0)
+	e.VoteWeighted(2, 2)
+	e.VoteE(0, 0)
+	e.VoteRanked([]int{0, 1}, []int{2})
+	e.VoteAll([][]int{{1, 2, 0}, {3}})
+	e.VoteAt(time.Now(), 2, 1)
+
+	rejected := 0
+	for _, ev := range events {
+		if ev.Err != nil {
+			rejected++
+		}
+	}
+	if len(events) != 8 || rejected != 2 {
+		t.Fatalf("%d events with %d rejections", len(events), rejected)
+	}
+	if ev := events[1]; ev.Count != 3 || ev.Weighted {
+		t.Errorf("unexpected event %+v", ev)
+	}
+	if ev := events[2]; ev.Count != 2 || !ev.Weighted {
+		t.Errorf("unexpected event %+v", ev)
+	}
+	if ev := events[3]; ev.Err != condorcet.ErrDuplicateCandidate {
+		t.Errorf("unexpected event %+v", ev)
+	}
+	if ev := events[4]; len(ev.Groups) != 2 || len(ev.Ballot) != 3 {
+		t.Errorf("unexpected event %+v", ev)
+	}
+}
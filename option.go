@@ -0,0 +1,41 @@
+package condorcet
+
+// Option configures an election created with New.
+type Option func(*Election)
+
+// Exact makes the election store its full profile,
+// i.e. the number of ballots for each of the n! possible total orders.
+//
+// Voting is then a constant time operation and the profile is available
+// from the result for exact analysis. The sum matrix is derived from the profile
+// when a result is created.
+//
+// It requires at most MaxExactCandidates candidates.
+func Exact() Option {
+	return func(e *Election) { e.exact = true }
+}
+
+// AllowTruncation makes the election accept ballots ranking only some of the candidates,
+// from the prefered one. Candidates missing from a ballot are tied last:
+// they lose against every ranked candidate and tie with each other.
+//
+// It cannot be combined with Exact.
+func AllowTruncation() Option {
+	return func(e *Election) { e.truncation = true }
+}
+
+// NoneOfTheAbove adds a reserved "none of the above" candidate to the election.
+// Its index is the number of candidates given to New,
+// so ballots must rank it like any other candidate.
+//
+// If it wins, the election has no valid winner.
+func NoneOfTheAbove() Option {
+	return func(e *Election) { e.nota = true }
+}
+
+// KemenyLimit sets the maximum number of candidates
+// for which the Kemeny-Young method is computed (see Result.Kemeny).
+// The default is DefaultKemenyLimit.
+func KemenyLimit(n int) Option {
+	return func(e *Election) { e.kemenyLimit = n }
+}
@@ -0,0 +1,55 @@
+// Package outcome is the result computation layer of the layered API (see package tally):
+// it computes outcomes from a tally.
+package outcome
+
+import (
+	"fmt"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/tally"
+)
+
+// Winner returns the Condorcet winner of the tally, if any:
+// the candidate preferred to each other candidate by more voters than the converse.
+// "None of the above" is never a valid winner (see condorcet.NoneOfTheAbove).
+func Winner(t *tally.Tally) (w int, exist bool) {
+	n := t.NumCandidates()
+	for c := 0; c < n; c++ {
+		wins := true
+		for o := 0; o < n && wins; o++ {
+			wins = o == c || t.Support(c, o) > t.Support(o, c)
+		}
+		if wins {
+			nota, ok := t.NOTA()
+			return c, !ok || c != nota
+		}
+	}
+	return 0, false
+}
+
+// Result returns the result of the tally in the current API,
+// from which the completion methods and reports are computed (see condorcet.NewResult).
+func Result(t *tally.Tally) (condorcet.Result, error) {
+	var opts []condorcet.Option
+	if _, ok := t.NOTA(); ok {
+		opts = append(opts, condorcet.NoneOfTheAbove())
+	}
+	if t.AllowsTruncation() {
+		opts = append(opts, condorcet.AllowTruncation())
+	}
+	return condorcet.NewResult(t.Matrix(), t.NumVoters(), opts...)
+}
+
+// Method returns the winner of the tally according to the named completion method
+// (see condorcet.Methods), false if several candidates tie.
+func Method(t *tally.Tally, name string) (w int, unique bool, err error) {
+	m, ok := condorcet.Methods(true)[name]
+	if !ok {
+		return 0, false, fmt.Errorf("unknown method %q", name)
+	}
+	r, err := Result(t)
+	if err != nil {
+		return 0, false, err
+	}
+	return m(r)
+}
@@ -0,0 +1,86 @@
+package outcome_test
+
+import (
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/collect"
+	"github.com/batiazinga/condorcet/outcome"
+	"github.com/batiazinga/condorcet/tally"
+)
+
+// TestLayers runs an election through the three layers
+// and compares it with the current API.
+func TestLayers(t *testing.T) {
+	ballots := map[string][]int{
+		"alice": {2, 0, 1},
+		"bob":   {2, 1, 0},
+		"carol": {0, 1, 2},
+	}
+
+	tl, _ := tally.New(3)
+	box := collect.NewBox(tl, false)
+	e, _ := condorcet.New(3)
+	for voter, ballot := range ballots {
+		if err := box.Cast(voter, ballot...); err != nil {
+			t.Fatal(err)
+		}
+		e.Vote(ballot...)
+	}
+
+	w, exist := outcome.Winner(box.Tally())
+	if want, _ := e.Result().Winner(); !exist || w != want {
+		t.Errorf("unexpected winner (%d, %v)", w, exist)
+	}
+	for name, method := range condorcet.Methods(false) {
+		w, unique, err := outcome.Method(box.Tally(), name)
+		want, wantUnique, _ := method(e.Result())
+		if err != nil || w != want || unique != wantUnique {
+			t.Errorf("%s: outcome (%d, %v, %v) instead of (%d, %v)", name, w, unique, err, want, wantUnique)
+		}
+	}
+	if _, _, err := outcome.Method(box.Tally(), "Unknown"); err == nil {
+		t.Error("unknown method accepted")
+	}
+}
+
+// TestWinner_NOTA checks that the outcome layer applies the rules of the current API.
+func TestWinner_NOTA(t *testing.T) {
+	tl, _ := tally.New(2, tally.NoneOfTheAbove())
+	tl.Add(1, []int{2}, []int{0}, []int{1})
+	if _, exist := outcome.Winner(tl); exist {
+		t.Error("none of the above reported as a valid winner")
+	}
+	if w, unique, err := outcome.Method(tl, "Schulze"); err != nil || unique {
+		t.Errorf("none of the above elected by Schulze (%d, %v, %v)", w, unique, err)
+	}
+}
+
+// TestResult checks that a tally with ties and truncated ballots
+// has the same outcome as an election of the current API.
+func TestResult(t *testing.T) {
+	ballots := [][][]int{
+		{{0}, {1, 2}},
+		{{2}, {0}},
+		{{1, 2}, {0}},
+		{{2}},
+	}
+	tl, _ := tally.New(3, tally.AllowTruncation())
+	e, _ := condorcet.New(3, condorcet.AllowTruncation())
+	for _, groups := range ballots {
+		if !tl.Add(2, groups...) || !e.VoteRankedN(2, groups...) {
+			t.Fatalf("ballot %v rejected", groups)
+		}
+	}
+
+	r, err := outcome.Result(tl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Equal(e.Result()) {
+		t.Errorf("result %v instead of %v", r.Matrix(), e.Result().Matrix())
+	}
+	if w, exist := outcome.Winner(tl); !exist || w != 2 {
+		t.Errorf("unexpected winner (%d, %v)", w, exist)
+	}
+}
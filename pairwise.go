@@ -0,0 +1,92 @@
+package condorcet
+
+import "errors"
+
+// Pairwise returns the number of voters preferring candidate i to candidate j.
+// It returns 0 if i and j are the same or unknown candidates.
+//
+// The pairwise counts are the raw tally: candidates excluded by the eligibility hook are included.
+func (r Result) Pairwise(i, j int) int {
+	if i == j || i < 0 || j < 0 || i >= r.e.num() || j >= r.e.num() {
+		return 0
+	}
+	return r.e.m.at(i, j)
+}
+
+// Matrix returns a copy of the pairwise matrix:
+// entry [i][j] is the number of voters preferring i to j (see Pairwise).
+// The diagonal is zero.
+func (r Result) Matrix() [][]int {
+	m := make([][]int, r.e.num())
+	for i := range m {
+		m[i] = r.row(i)
+	}
+	return m
+}
+
+// NewResult returns the result of a pairwise matrix tallied outside of an Election,
+// e.g. by package tally: entry [i][j] is the number of the voters preferring i to j.
+// The options shape the tally like for New:
+// with NoneOfTheAbove, the last candidate of the matrix is "none of the above".
+// The ballots are unknown: methods requiring them return ErrNoProfile.
+// It returns an error if the matrix is inconsistent with the voters (see Result.Verify).
+func NewResult(pairwise [][]int, voters int, opts ...Option) (Result, error) {
+	e, err := New(2, opts...)
+	if err != nil {
+		return Result{}, err
+	}
+	if e.exact {
+		return Result{}, ErrNoProfile
+	}
+	if voters < 0 {
+		return Result{}, errors.New("negative number of voters")
+	}
+
+	doc := e.state()
+	doc.Candidates = len(pairwise)
+	doc.Voters, doc.Ballots = voters, voters
+	doc.Pairwise = pairwise
+	// pairs compared by fewer voters come from ballots with ties
+	for i := range pairwise {
+		for j := i + 1; j < len(pairwise[i]) && j < len(pairwise); j++ {
+			if i < len(pairwise[j]) && pairwise[i][j]+pairwise[j][i] != voters {
+				doc.Ties = !doc.Truncation
+			}
+		}
+	}
+	if err := e.restore(doc); err != nil {
+		return Result{}, err
+	}
+	return e.Result(), nil
+}
+
+// row returns a copy of the row of candidate i in the pairwise matrix.
+func (r Result) row(i int) []int {
+	row := make([]int, r.e.num())
+	for j := range row {
+		if j != i {
+			row[j] = r.e.m.at(i, j)
+		}
+	}
+	return row
+}
+
+// Margin returns the margin of candidate i over candidate j:
+// the number of voters preferring i to j minus the number of voters preferring j to i.
+// It returns 0 if i and j are the same or unknown candidates.
+func (r Result) Margin(i, j int) int { return r.Pairwise(i, j) - r.Pairwise(j, i) }
+
+// Margins returns the matrix of the margins:
+// entry [i][j] is the margin of i over j (see Margin).
+// It is antisymmetric.
+func (r Result) Margins() [][]int {
+	n := r.e.num()
+	m := make([][]int, n)
+	for i := range m {
+		m[i] = make([]int, n)
+		for j := range m[i] {
+			m[i][j] = r.Margin(i, j)
+		}
+	}
+	return m
+}
@@ -0,0 +1,73 @@
+package condorcet_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_Matrix checks the pairwise matrix of Condorcet's example.
+func TestResult_Matrix(t *testing.T) {
+	r := result(t, "Condorcet's example")
+	want := [][]int{
+		{0, 25, 23},
+		{35, 0, 19},
+		{37, 41, 0},
+	}
+	m := r.Matrix()
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("matrix is %v instead of %v", m, want)
+	}
+	m[0][1] = 0
+	if r.Pairwise(0, 1) != 25 {
+		t.Error("matrix is not a copy")
+	}
+
+	for _, pair := range [][2]int{{0, 0}, {-1, 0}, {0, 3}} {
+		if n := r.Pairwise(pair[0], pair[1]); n != 0 {
+			t.Errorf("pair %v has %d voters", pair, n)
+		}
+	}
+}
+
+// TestResult_Margins checks the margins of Condorcet's example.
+func TestResult_Margins(t *testing.T) {
+	r := result(t, "Condorcet's example")
+	want := [][]int{
+		{0, -10, -14},
+		{10, 0, -22},
+		{14, 22, 0},
+	}
+	if m := r.Margins(); !reflect.DeepEqual(m, want) {
+		t.Errorf("margins are %v instead of %v", m, want)
+	}
+	if m := r.Margin(2, 1); m != 22 {
+		t.Errorf("margin of 2 over 1 is %d instead of 22", m)
+	}
+}
+
+// TestNewResult rebuilds the result of Condorcet's example from its matrix.
+func TestNewResult(t *testing.T) {
+	want := result(t, "Condorcet's example")
+	r, err := condorcet.NewResult(want.Matrix(), want.NumVoters())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Equal(want) {
+		t.Errorf("unexpected result %v", r.Matrix())
+	}
+	if _, _, _, err := r.SmithIRV(); err != condorcet.ErrNoProfile {
+		t.Errorf("unexpected error without ballots: %v", err)
+	}
+
+	for _, m := range [][][]int{
+		{{0, 25, 23}, {35, 0, 19}, {37, 42, 0}},
+		{{0, 25, 23}, {35, 0, 19}},
+		{{1, 25, 23}, {35, 0, 19}, {37, 41, 0}},
+	} {
+		if _, err := condorcet.NewResult(m, 60); err == nil {
+			t.Errorf("inconsistent matrix %v accepted", m)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package paper
+
+import (
+	"errors"
+	"html/template"
+	"io"
+)
+
+// Sheet describes a printable ballot.
+type Sheet struct {
+	Election   string   // identifier of the election, printed on the ballot
+	Title      string   // title of the election
+	Candidates []string // names of the candidates, in the order of the election
+}
+
+// sheetTemplate is a print-ready HTML ballot.
+// Each candidate has one box per rank.
+var sheetTemplate = template.Must(template.New("sheet").Funcs(template.FuncMap{
+	"inc": func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+@page { size: A4; margin: 2cm; }
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { padding: 0.4em; text-align: center; }
+td.candidate { text-align: left; }
+span.box { display: inline-block; width: 1.2em; height: 1.2em; border: 1px solid black; }
+footer { margin-top: 2em; font-family: monospace; }
+</style>
+</head>
+<body data-election="{{.Election}}">
+<h1>{{.Title}}</h1>
+<p>Rank every candidate: tick exactly one box per line and per column, 1 being your preferred candidate.</p>
+<table>
+<tr><th></th>{{range $rank, $_ := .Candidates}}<th>{{inc $rank}}</th>{{end}}</tr>
+{{range .Candidates}}<tr><td class="candidate">{{.}}</td>{{range $.Candidates}}<td><span class="box"></span></td>{{end}}</tr>
+{{end}}</table>
+<footer>Election {{.Election}}</footer>
+</body>
+</html>
+`))
+
+// WriteHTML writes the ballot as a print-ready HTML document.
+func (s Sheet) WriteHTML(w io.Writer) error {
+	if s.Election == "" {
+		return errors.New("missing election identifier")
+	}
+	if len(s.Candidates) < 2 {
+		return errors.New("expecting at least 2 candidates")
+	}
+	return sheetTemplate.Execute(w, s)
+}
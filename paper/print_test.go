@@ -0,0 +1,32 @@
+package paper_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/batiazinga/condorcet/paper"
+)
+
+// TestSheet_WriteHTML asserts that the printable ballot contains the election and the candidates.
+func TestSheet_WriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	s := paper.Sheet{Election: "board-2020", Title: "Board", Candidates: []string{"Alice", "Bob <3"}}
+	if err := s.WriteHTML(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	html := buf.String()
+	for _, want := range []string{`data-election="board-2020"`, "Alice", "Bob &lt;3", "<th>2</th>"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("%q is missing from the ballot", want)
+		}
+	}
+	if strings.Count(html, `class="box"`) != 4 {
+		t.Errorf("expecting 4 ranking boxes")
+	}
+
+	if err := (paper.Sheet{Candidates: []string{"A", "B"}}).WriteHTML(&buf); err == nil {
+		t.Error("ballot without election identifier accepted")
+	}
+}
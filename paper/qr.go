@@ -0,0 +1,145 @@
+// Package paper supports hybrid paper/digital voting workflows:
+// printable ballots and QR codes of completed ballots.
+package paper
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"strings"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// payloadPrefix identifies the payload format and its version.
+const payloadPrefix = "CDT1"
+
+// Ballot is a completed ballot, as printed in a QR code.
+type Ballot struct {
+	Election string // identifier of the election
+	Ranking  []int  // total order over the candidates
+	Receipt  string // receipt given to the voter, unique to the ballot (see NewReceipt)
+}
+
+// NewReceipt returns a random receipt:
+// a nonce of 128 bits, so that two ballots never share a receipt,
+// even with the same ranking.
+func NewReceipt() (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(nonce), nil
+}
+
+// errNoReceipt is returned for ballots without receipt.
+var errNoReceipt = errors.New("missing receipt")
+
+// Payload returns the content of the QR code of the ballot.
+//
+// The ballot must have a receipt (see NewReceipt),
+// and the election identifier and the receipt must not contain colons.
+// The ranking is stored as its Lehmer code (see condorcet.EncodeBallot)
+// and the payload is protected by a checksum against scanning errors.
+func (b Ballot) Payload() (string, error) {
+	if strings.Contains(b.Election, ":") || strings.Contains(b.Receipt, ":") {
+		return "", errors.New("election identifier and receipt must not contain colons")
+	}
+	if b.Receipt == "" {
+		return "", errNoReceipt
+	}
+	code, err := condorcet.EncodeBallot(b.Ranking)
+	if err != nil {
+		return "", err
+	}
+
+	data := strings.Join(
+		[]string{
+			payloadPrefix,
+			b.Election,
+			strconv.Itoa(len(b.Ranking)),
+			strconv.FormatUint(code, 36),
+			b.Receipt,
+		},
+		":",
+	)
+	return data + ":" + checksum(data), nil
+}
+
+// checksum returns the checksum of the payload data.
+func checksum(data string) string {
+	return fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(data)))
+}
+
+// DecodePayload returns the ballot stored in a QR code payload.
+func DecodePayload(payload string) (Ballot, error) {
+	fields := strings.Split(payload, ":")
+	if len(fields) != 6 || fields[0] != payloadPrefix {
+		return Ballot{}, errors.New("not a ballot payload")
+	}
+	data := strings.Join(fields[:5], ":")
+	if checksum(data) != fields[5] {
+		return Ballot{}, errors.New("checksum mismatch")
+	}
+	if fields[4] == "" {
+		return Ballot{}, errNoReceipt
+	}
+
+	n, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return Ballot{}, fmt.Errorf("invalid number of candidates: %v", err)
+	}
+	code, err := strconv.ParseUint(fields[3], 36, 64)
+	if err != nil {
+		return Ballot{}, fmt.Errorf("invalid ranking: %v", err)
+	}
+	ranking, err := condorcet.DecodeBallot(code, n)
+	if err != nil {
+		return Ballot{}, err
+	}
+
+	return Ballot{
+		Election: fields[1],
+		Ranking:  ranking,
+		Receipt:  fields[4],
+	}, nil
+}
+
+// Scanner tallies scanned ballots into an election.
+// A receipt can be tallied only once.
+type Scanner struct {
+	election string
+	e        *condorcet.Election
+	receipts map[string]bool
+}
+
+// NewScanner returns a scanner tallying the ballots of the identified election into e.
+func NewScanner(election string, e *condorcet.Election) *Scanner {
+	return &Scanner{
+		election: election,
+		e:        e,
+		receipts: make(map[string]bool),
+	}
+}
+
+// Scan validates and tallies the ballot stored in a QR code payload.
+func (s *Scanner) Scan(payload string) error {
+	b, err := DecodePayload(payload)
+	if err != nil {
+		return err
+	}
+	if b.Election != s.election {
+		return fmt.Errorf("ballot belongs to election %q", b.Election)
+	}
+	if s.receipts[b.Receipt] {
+		return fmt.Errorf("receipt %q was already scanned", b.Receipt)
+	}
+	if !s.e.Vote(b.Ranking...) {
+		return errors.New("ballot does not match the election")
+	}
+	s.receipts[b.Receipt] = true
+	return nil
+}
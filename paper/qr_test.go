@@ -0,0 +1,93 @@
+package paper_test
+
+import (
+	"fmt"
+	"hash/crc32"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/paper"
+)
+
+// TestBallot_Payload asserts that a payload decodes to the original ballot.
+func TestBallot_Payload(t *testing.T) {
+	b := paper.Ballot{Election: "board-2020", Ranking: []int{2, 0, 3, 1}, Receipt: "R42"}
+	payload, err := b.Payload()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := paper.DecodePayload(payload)
+	if err != nil {
+		t.Fatalf("cannot decode %q: %v", payload, err)
+	}
+	if !reflect.DeepEqual(decoded, b) {
+		t.Errorf("decoded %+v instead of %+v", decoded, b)
+	}
+
+	// corrupted payload
+	corrupted := strings.Replace(payload, "R42", "R43", 1)
+	if _, err := paper.DecodePayload(corrupted); err == nil {
+		t.Error("corrupted payload accepted")
+	}
+}
+
+// TestScanner_Scan asserts that scanned ballots are tallied once and for the right election.
+func TestScanner_Scan(t *testing.T) {
+	e, _ := condorcet.New(3)
+	s := paper.NewScanner("e1", e)
+
+	payload, _ := paper.Ballot{Election: "e1", Ranking: []int{1, 0, 2}, Receipt: "a"}.Payload()
+	if err := s.Scan(payload); err != nil {
+		t.Fatalf("valid ballot rejected: %v", err)
+	}
+	if err := s.Scan(payload); err == nil {
+		t.Error("ballot scanned twice")
+	}
+
+	other, _ := paper.Ballot{Election: "e2", Ranking: []int{1, 0, 2}, Receipt: "b"}.Payload()
+	if err := s.Scan(other); err == nil {
+		t.Error("ballot of another election accepted")
+	}
+
+	if e.NumVoters() != 1 {
+		t.Errorf("%d voters instead of 1", e.NumVoters())
+	}
+}
+
+// TestNewReceipt asserts that ballots get distinct receipts and that a receipt is required.
+func TestNewReceipt(t *testing.T) {
+	r1, err := paper.NewReceipt()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2, _ := paper.NewReceipt()
+	if r1 == "" || r1 == r2 {
+		t.Errorf("receipts %q and %q are not distinct", r1, r2)
+	}
+
+	// identical rankings, distinct payloads
+	p1, err := paper.Ballot{Election: "e1", Ranking: []int{1, 0}, Receipt: r1}.Payload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2, _ := paper.Ballot{Election: "e1", Ranking: []int{1, 0}, Receipt: r2}.Payload()
+	e, _ := condorcet.New(2)
+	s := paper.NewScanner("e1", e)
+	if err := s.Scan(p1); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Scan(p2); err != nil {
+		t.Errorf("identical ranking with another receipt rejected: %v", err)
+	}
+
+	if _, err := (paper.Ballot{Election: "e1", Ranking: []int{1, 0}}).Payload(); err == nil {
+		t.Error("ballot without receipt accepted")
+	}
+	data := "CDT1:e1:2:1:"
+	if _, err := paper.DecodePayload(data + ":" + fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(data)))); err == nil {
+		t.Error("payload without receipt accepted")
+	}
+}
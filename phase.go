@@ -0,0 +1,62 @@
+package condorcet
+
+import (
+	"context"
+	"runtime/pprof"
+	"time"
+)
+
+// PhaseHook is called at the end of each phase, from bulk ingestion to results,
+// with the name of the phase and the time it took.
+//
+// Phases are also labelled with the "condorcet" pprof label,
+// so that CPU profiles of embedding services can be attributed to them.
+type PhaseHook func(phase string, elapsed time.Duration)
+
+// Names of the phases reported to a PhaseHook.
+const (
+	PhaseSnapshot = "snapshot" // copy of the election into a result
+	PhaseWinner   = "winner"   // computation of the Condorcet winner
+	PhaseSchulze  = "schulze"  // Schulze method
+	PhaseCopeland = "copeland" // Copeland method
+	PhaseMinimax  = "minimax"  // Minimax method
+	PhaseKemeny   = "kemeny"   // Kemeny-Young method
+	PhaseBaldwin  = "baldwin"  // Baldwin method
+	PhaseDodgson  = "dodgson"  // approximation of the Dodgson scores
+	PhaseBlack    = "black"    // Borda fallback of Black's method
+	PhaseSmithIRV = "smithirv" // Smith//IRV method
+	PhaseRiver    = "river"    // River method
+	PhaseTideman  = "tideman"  // Tideman's Alternative method
+	PhaseYoung    = "young"    // Young scores
+	PhaseIngest   = "ingest"   // bulk ingestion of ballots by VoteAll or VoteProfile
+	PhaseMerge    = "merge"    // merge of tallies, by Merge or of the shards of a sharded election
+)
+
+// SetPhaseHook registers a hook receiving the duration of each phase.
+// A nil hook disables the reporting.
+//
+// Results created after the call inherit the hook.
+func (e *Election) SetPhaseHook(h PhaseHook) { e.hook = h }
+
+// do runs f with the pprof labels of the phase
+// and reports its duration to the hook, if any.
+func (e *Election) do(phase string, f func()) {
+	start := time.Now()
+	pprof.Do(
+		context.Background(),
+		pprof.Labels("condorcet", phase),
+		func(context.Context) { f() },
+	)
+	if e.hook != nil {
+		e.hook(phase, time.Since(start))
+	}
+}
+
+// stopper returns a function reporting whether ctx is done,
+// to abandon long computations, or nil if ctx is never done.
+func stopper(ctx context.Context) func() bool {
+	if ctx.Done() == nil {
+		return nil
+	}
+	return func() bool { return ctx.Err() != nil }
+}
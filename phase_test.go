@@ -0,0 +1,56 @@
+package condorcet_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestElection_SetPhaseHook asserts that the hook receives the snapshot and winner phases.
+func TestElection_SetPhaseHook(t *testing.T) {
+	var phases []string
+	e := &condorcet.Election{}
+	e.SetPhaseHook(func(phase string, elapsed time.Duration) {
+		if elapsed < 0 {
+			t.Errorf("negative duration for phase %q", phase)
+		}
+		phases = append(phases, phase)
+	})
+
+	e.Vote(0, 1)
+	e.Result().Winner()
+
+	if len(phases) != 2 || phases[0] != condorcet.PhaseSnapshot || phases[1] != condorcet.PhaseWinner {
+		t.Errorf("unexpected phases: %v", phases)
+	}
+}
+
+// TestElection_SetPhaseHookIngest asserts that the hook receives the ingestion and merge phases.
+func TestElection_SetPhaseHookIngest(t *testing.T) {
+	var phases []string
+	e, _ := condorcet.New(3)
+	e.SetPhaseHook(func(phase string, elapsed time.Duration) { phases = append(phases, phase) })
+
+	e.VoteAll([][]int{{0, 1, 2}, {2, 1, 0}})
+	p := &condorcet.Profile{}
+	p.Add(2, 1, 0, 2)
+	e.VoteProfile(p)
+	other, _ := condorcet.New(3)
+	other.Vote(0, 2, 1)
+	e.Merge(other)
+
+	want := []string{condorcet.PhaseIngest, condorcet.PhaseIngest, condorcet.PhaseMerge}
+	if len(phases) != len(want) {
+		t.Fatalf("unexpected phases: %v", phases)
+	}
+	for k := range want {
+		if phases[k] != want[k] {
+			t.Errorf("unexpected phases: %v", phases)
+			break
+		}
+	}
+	if e.NumVoters() != 5 {
+		t.Errorf("%d voters instead of 5", e.NumVoters())
+	}
+}
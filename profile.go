@@ -0,0 +1,126 @@
+package condorcet
+
+import "encoding/binary"
+
+// MaxExactCandidates is the largest number of candidates
+// of an election storing its full profile (see Exact).
+const MaxExactCandidates = 8
+
+// sync rebuilds the sum matrix from the profile,
+// if ballots were received since the last synchronization.
+func (e *Election) sync() {
+	if !e.dirty {
+		return
+	}
+
+	e.m = e.newMatrix()
+	for code, count := range e.p {
+		if count == 0 {
+			continue
+		}
+		ballot, _ := DecodeBallot(uint64(code), e.num())
+		e.add(ballot, count)
+	}
+	e.dirty = false
+}
+
+// Profile returns the number of ballots for each total order,
+// indexed by the Lehmer code of the order (see DecodeBallot).
+//
+// It returns nil if the election does not store its full profile (see Exact).
+func (r Result) Profile() []int {
+	if r.e.p == nil {
+		return nil
+	}
+
+	p := make([]int, len(r.e.p))
+	copy(p, r.e.p)
+	return p
+}
+
+// Profile is a multiset of ballots: the distinct ballots and how many times each was cast,
+// e.g. aggregated data.
+// Identical ballots are stored once, so that an election tallies them in one step (see VoteProfile).
+//
+// The zero value is an empty profile.
+// Ballots are kept in order of first insertion and are not validated until they are voted.
+type Profile struct {
+	index   map[string]int // position of the ballots, by key
+	ballots [][]int
+	counts  []int
+	total   int
+	key     []byte // scratch key, see add
+}
+
+// Add adds count copies of the ballot to the profile.
+// It returns false if count is zero or if the number of ballots would overflow.
+func (p *Profile) Add(count uint, ballot ...int) bool {
+	if count == 0 || count > maxInt-uint(p.total) {
+		return false
+	}
+	p.add(ballot, int(count))
+	return true
+}
+
+// add implements Add.
+func (p *Profile) add(ballot []int, count int) {
+	var buf [binary.MaxVarintLen64]byte
+	p.key = p.key[:0]
+	for _, c := range ballot {
+		p.key = append(p.key, buf[:binary.PutVarint(buf[:], int64(c))]...)
+	}
+	p.total += count
+
+	if k, ok := p.index[string(p.key)]; ok {
+		p.counts[k] += count
+		return
+	}
+	if p.index == nil {
+		p.index = make(map[string]int)
+	}
+	p.index[string(p.key)] = len(p.ballots)
+	p.ballots = append(p.ballots, append([]int(nil), ballot...))
+	p.counts = append(p.counts, count)
+}
+
+// Merge adds the ballots of other to the profile.
+// It returns false, leaving the profile unchanged, if the number of ballots would overflow.
+func (p *Profile) Merge(other *Profile) bool {
+	if uint(other.total) > maxInt-uint(p.total) {
+		return false
+	}
+	for k, ballot := range other.ballots {
+		p.add(ballot, other.counts[k])
+	}
+	return true
+}
+
+// Len returns the number of distinct ballots.
+func (p *Profile) Len() int { return len(p.ballots) }
+
+// NumBallots returns the number of ballots, counting copies.
+func (p *Profile) NumBallots() int { return p.total }
+
+// Ballot returns the k-th distinct ballot, in order of first insertion, and its number of copies.
+// The ballot must not be modified.
+func (p *Profile) Ballot(k int) (ballot []int, count int) { return p.ballots[k], p.counts[k] }
+
+// VoteProfile registers the ballots of the profile, like VoteN for each distinct ballot in order:
+// each distinct ballot is validated, written to the journal and tallied once.
+// It returns the number of accepted ballots, counting copies,
+// and the error of the first rejected distinct ballot (see VoteE);
+// rejected ballots do not stop the tally.
+func (e *Election) VoteProfile(p *Profile) (accepted int, firstErr error) {
+	e.do(PhaseIngest, func() {
+		for k, ballot := range p.ballots {
+			if err := e.vote(ballot, uint(p.counts[k]), false); err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			accepted += p.counts[k]
+		}
+	})
+	return accepted, firstErr
+}
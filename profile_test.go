@@ -0,0 +1,110 @@
+package condorcet_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestExact_invalid asserts that an exact election cannot have too many candidates.
+func TestExact_invalid(t *testing.T) {
+	if _, err := condorcet.New(condorcet.MaxExactCandidates+1, condorcet.Exact()); err == nil {
+		t.Fatalf("creating an exact election with too many candidates did not fail")
+	}
+}
+
+// TestExact_Winner asserts that an exact election has the same outcome as a regular one.
+func TestExact_Winner(t *testing.T) {
+	for i, tc := range testcases {
+		t.Run(
+			strconv.Itoa(i),
+			func(t *testing.T) {
+				e, err := condorcet.New(tc.num, condorcet.Exact())
+				if err != nil {
+					t.Errorf("testcase %q is invalid: %v", tc.label, err)
+					return
+				}
+
+				var numVoters int
+				for _, ballot := range tc.ballots {
+					numVoters += ballot[0]
+					for k := 0; k < ballot[0]; k++ {
+						e.Vote(ballot[1:]...)
+					}
+				}
+
+				if e.NumVoters() != numVoters {
+					t.Errorf("wrong number of voters: %d instead of %d", e.NumVoters(), numVoters)
+				}
+
+				r := e.Result()
+				w, exist := r.Winner()
+				if exist != tc.hasWinner || (exist && w != tc.winner) {
+					t.Errorf("wrong winner: (%d, %v) instead of (%d, %v)", w, exist, tc.winner, tc.hasWinner)
+				}
+
+				// the profile must contain every ballot
+				var total int
+				for _, count := range r.Profile() {
+					total += count
+				}
+				if total != numVoters {
+					t.Errorf("profile contains %d ballots instead of %d", total, numVoters)
+				}
+			},
+		)
+	}
+}
+
+// TestProfile checks that a profile tallies like the ballots it aggregates.
+func TestProfile(t *testing.T) {
+	for _, tc := range testcases {
+		var p, half condorcet.Profile
+		for _, ballot := range tc.ballots {
+			p.Add(uint(ballot[0]), ballot[1:]...)
+			half.Add(uint(ballot[0]), ballot[1:]...)
+		}
+		if !p.Merge(&half) {
+			t.Fatalf("%s: merge refused", tc.label)
+		}
+		if p.Len() > len(tc.ballots) || p.NumBallots() != 2*result(t, tc.label).NumVoters() {
+			t.Errorf("%s: %d distinct ballots, %d ballots", tc.label, p.Len(), p.NumBallots())
+		}
+
+		e, _ := condorcet.New(tc.num)
+		accepted, err := e.VoteProfile(&p)
+		if err != nil || accepted != p.NumBallots() {
+			t.Errorf("%s: %d ballots accepted: %v", tc.label, accepted, err)
+		}
+		want, _ := condorcet.New(tc.num)
+		for _, ballot := range tc.ballots {
+			want.VoteN(2*uint(ballot[0]), ballot[1:]...)
+		}
+		if !e.Result().Equal(want.Result()) {
+			t.Errorf("%s: profile tally differs", tc.label)
+		}
+	}
+
+	// identical ballots are stored once
+	var p condorcet.Profile
+	p.Add(2, 0, 1, 2)
+	p.Add(3, 1, 0, 2)
+	p.Add(1, 0, 1, 2)
+	p.Add(4, 0, 1)
+	if p.Add(0, 2, 1, 0) {
+		t.Error("ballot added zero times")
+	}
+	if ballot, count := p.Ballot(0); p.Len() != 3 || count != 3 || ballot[0] != 0 {
+		t.Errorf("%d distinct ballots, first one %v cast %d times", p.Len(), ballot, count)
+	}
+
+	// the truncated ballot is rejected
+	e, _ := condorcet.New(3)
+	if accepted, err := e.VoteProfile(&p); accepted != 6 || err != condorcet.ErrWrongLength {
+		t.Errorf("%d ballots accepted: %v", accepted, err)
+	}
+	if e.NumBallots() != 6 {
+		t.Errorf("%d ballots instead of 6", e.NumBallots())
+	}
+}
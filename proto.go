@@ -0,0 +1,374 @@
+package condorcet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Protocol Buffers codecs of the messages defined in condorcet.proto.
+// The wire format is simple enough to be written by hand,
+// which keeps the package free of dependencies.
+
+// errMalformedProto is returned when decoding an invalid protobuf message.
+var errMalformedProto = errors.New("malformed protobuf message")
+
+// MarshalProto returns the encoding of the ballot as a Ballot message of condorcet.proto.
+// Candidates must not be negative.
+func (b Ballot) MarshalProto() ([]byte, error) {
+	for _, c := range b {
+		if c < 0 {
+			return nil, ErrCandidateOutOfRange
+		}
+	}
+	var p protoEncoder
+	p.packed(1, b)
+	return p, nil
+}
+
+// UnmarshalProto decodes a Ballot message of condorcet.proto.
+// It does not validate the ballot (see Validate).
+func (b *Ballot) UnmarshalProto(data []byte) error {
+	var order Ballot
+	err := decodeProto(data, func(f protoField) error {
+		if f.num != 1 {
+			return nil
+		}
+		cs, err := f.ints()
+		order = append(order, cs...)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	*b = order
+	return nil
+}
+
+// MarshalProto returns the encoding of the election as an Election message of condorcet.proto.
+// Like MarshalJSON, it persists the tally, the names and the metadata of the candidates,
+// but not the provisional and timestamped ballots.
+func (e *Election) MarshalProto() ([]byte, error) { return encodeElection(e.state()), nil }
+
+// UnmarshalProto decodes an Election message of condorcet.proto.
+// Like UnmarshalJSON, it checks the consistency of the state
+// and keeps the sanitizers, journal and hooks of the receiver.
+func (e *Election) UnmarshalProto(data []byte) error {
+	doc, err := decodeElection(data)
+	if err != nil {
+		return err
+	}
+	return e.restore(doc)
+}
+
+// MarshalProto returns the encoding of the result as a Result message of condorcet.proto.
+func (r Result) MarshalProto() ([]byte, error) {
+	var p protoEncoder
+	p.bytes(1, encodeElection(r.e.state()))
+	for _, x := range r.e.exclusions {
+		var q protoEncoder
+		q.int(1, x.Candidate)
+		q.string(2, x.Reason)
+		p.bytes(2, q)
+	}
+	if w, exist := r.Winner(); exist {
+		p.tag(3, 0)
+		p.varint(uint64(w))
+	}
+	return p, nil
+}
+
+// UnmarshalProto decodes a Result message of condorcet.proto.
+// The winner is recomputed and must match the encoded one.
+func (r *Result) UnmarshalProto(data []byte) error {
+	var (
+		doc    electionState
+		winner = -1
+	)
+	err := decodeProto(data, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			doc, err = decodeElection(f.b)
+		case 2:
+			var x Exclusion
+			err = decodeProto(f.b, func(f protoField) error {
+				switch f.num {
+				case 1:
+					x.Candidate = f.int()
+				case 2:
+					x.Reason = string(f.b)
+				}
+				return nil
+			})
+			doc.Exclusions = append(doc.Exclusions, x)
+		case 3:
+			winner = f.int()
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	e := new(Election)
+	if err := e.restore(doc); err != nil {
+		return err
+	}
+	loaded := Result{e}
+	if w, exist := loaded.Winner(); (exist && w != winner) || (!exist && winner != -1) {
+		return fmt.Errorf("encoded winner %d does not match the tally", winner)
+	}
+	*r = loaded
+	return nil
+}
+
+// encodeElection returns the encoding of the state as an Election message.
+func encodeElection(doc electionState) []byte {
+	var p protoEncoder
+	p.int(1, doc.Version)
+	p.int(2, doc.Candidates)
+	p.bool(3, doc.NOTA)
+	p.bool(4, doc.Truncation)
+	p.bool(5, doc.Ties)
+	p.bool(12, doc.Exact)
+	p.int(6, doc.Voters)
+	p.int(7, doc.Ballots)
+	var pairwise []int
+	for _, row := range doc.Pairwise {
+		pairwise = append(pairwise, row...)
+	}
+	p.packed(8, pairwise)
+	p.packed(9, doc.Profile)
+	for _, name := range doc.Names {
+		p.bytes(10, []byte(name))
+	}
+	for _, m := range doc.Metadata {
+		var q protoEncoder
+		q.string(1, m.DisplayName)
+		q.string(2, m.Party)
+		q.string(3, m.URL)
+		keys := make([]string, 0, len(m.Extra))
+		for k := range m.Extra {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			var entry protoEncoder
+			entry.string(1, k)
+			entry.string(2, m.Extra[k])
+			q.bytes(4, entry)
+		}
+		p.bytes(11, q)
+	}
+	return p
+}
+
+// decodeElection decodes an Election message.
+func decodeElection(data []byte) (electionState, error) {
+	var (
+		doc      electionState
+		pairwise []int
+	)
+	err := decodeProto(data, func(f protoField) (err error) {
+		var values []int
+		switch f.num {
+		case 1:
+			doc.Version = f.int()
+		case 2:
+			doc.Candidates = f.int()
+		case 3:
+			doc.NOTA = f.v != 0
+		case 4:
+			doc.Truncation = f.v != 0
+		case 5:
+			doc.Ties = f.v != 0
+		case 12:
+			doc.Exact = f.v != 0
+		case 6:
+			doc.Voters = f.int()
+		case 7:
+			doc.Ballots = f.int()
+		case 8:
+			values, err = f.ints()
+			pairwise = append(pairwise, values...)
+		case 9:
+			values, err = f.ints()
+			doc.Profile = append(doc.Profile, values...)
+		case 10:
+			doc.Names = append(doc.Names, string(f.b))
+		case 11:
+			var m Metadata
+			err = decodeProto(f.b, func(f protoField) error {
+				switch f.num {
+				case 1:
+					m.DisplayName = string(f.b)
+				case 2:
+					m.Party = string(f.b)
+				case 3:
+					m.URL = string(f.b)
+				case 4:
+					var k, v string
+					if err := decodeProto(f.b, func(f protoField) error {
+						if f.num == 1 {
+							k = string(f.b)
+						} else if f.num == 2 {
+							v = string(f.b)
+						}
+						return nil
+					}); err != nil {
+						return err
+					}
+					if m.Extra == nil {
+						m.Extra = make(map[string]string)
+					}
+					m.Extra[k] = v
+				}
+				return nil
+			})
+			doc.Metadata = append(doc.Metadata, m)
+		}
+		return err
+	})
+	if err != nil {
+		return doc, err
+	}
+
+	n := doc.Candidates
+	if pairwise != nil {
+		if n < 2 || len(pairwise) != n*n {
+			return doc, errors.New("pairwise matrix does not match the candidates")
+		}
+		for i := 0; i < n; i++ {
+			doc.Pairwise = append(doc.Pairwise, pairwise[i*n:(i+1)*n])
+		}
+	}
+	return doc, nil
+}
+
+// protoEncoder appends fields in the protobuf wire format.
+// Like proto3, it omits scalar fields with the zero value.
+type protoEncoder []byte
+
+// varint appends v as a varint.
+func (p *protoEncoder) varint(v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	*p = append(*p, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+// tag appends the key of a field.
+func (p *protoEncoder) tag(field, wire int) { p.varint(uint64(field<<3 | wire)) }
+
+// int appends an integer field.
+func (p *protoEncoder) int(field, v int) {
+	if v != 0 {
+		p.tag(field, 0)
+		p.varint(uint64(int64(v)))
+	}
+}
+
+// bool appends a boolean field.
+func (p *protoEncoder) bool(field int, v bool) {
+	if v {
+		p.int(field, 1)
+	}
+}
+
+// bytes appends a length-delimited field: bytes, string or embedded message.
+func (p *protoEncoder) bytes(field int, b []byte) {
+	p.tag(field, 2)
+	p.varint(uint64(len(b)))
+	*p = append(*p, b...)
+}
+
+// string appends a string field.
+func (p *protoEncoder) string(field int, s string) {
+	if s != "" {
+		p.bytes(field, []byte(s))
+	}
+}
+
+// packed appends a packed repeated integer field.
+func (p *protoEncoder) packed(field int, vs []int) {
+	if len(vs) == 0 {
+		return
+	}
+	var q protoEncoder
+	for _, v := range vs {
+		q.varint(uint64(int64(v)))
+	}
+	p.bytes(field, q)
+}
+
+// protoField is a field decoded from the protobuf wire format.
+type protoField struct {
+	num  int
+	wire int
+	v    uint64 // value of a varint field
+	b    []byte // value of a length-delimited field
+}
+
+// int returns the value of a varint field.
+func (f protoField) int() int { return int(int64(f.v)) }
+
+// ints returns the values of a repeated integer field, packed or not.
+func (f protoField) ints() ([]int, error) {
+	if f.wire == 0 {
+		return []int{f.int()}, nil
+	}
+	var vs []int
+	for b := f.b; len(b) > 0; {
+		v, k := binary.Uvarint(b)
+		if k <= 0 {
+			return nil, errMalformedProto
+		}
+		vs = append(vs, int(int64(v)))
+		b = b[k:]
+	}
+	return vs, nil
+}
+
+// decodeProto calls f on each field of the message, in order.
+// Fixed-size fields are skipped: no message of condorcet.proto has one.
+func decodeProto(data []byte, f func(protoField) error) error {
+	for len(data) > 0 {
+		key, k := binary.Uvarint(data)
+		if k <= 0 {
+			return errMalformedProto
+		}
+		data = data[k:]
+		field := protoField{num: int(key >> 3), wire: int(key & 7)}
+		switch field.wire {
+		case 0:
+			if field.v, k = binary.Uvarint(data); k <= 0 {
+				return errMalformedProto
+			}
+			data = data[k:]
+		case 1:
+			if len(data) < 8 {
+				return errMalformedProto
+			}
+			data = data[8:]
+			continue
+		case 2:
+			l, k := binary.Uvarint(data)
+			if k <= 0 || l > uint64(len(data)-k) {
+				return errMalformedProto
+			}
+			field.b = data[k : k+int(l)]
+			data = data[k+int(l):]
+		case 5:
+			if len(data) < 4 {
+				return errMalformedProto
+			}
+			data = data[4:]
+			continue
+		default:
+			return errMalformedProto
+		}
+		if err := f(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
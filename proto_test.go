@@ -0,0 +1,94 @@
+package condorcet_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestBallot_MarshalProto checks the round trip of a ballot.
+func TestBallot_MarshalProto(t *testing.T) {
+	data, err := condorcet.Ballot{2, 0, 1}.MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// field 1, packed: 3 bytes
+	if want := []byte{0x0a, 3, 2, 0, 1}; !reflect.DeepEqual(data, want) {
+		t.Errorf("encoding is %v instead of %v", data, want)
+	}
+	var b condorcet.Ballot
+	if err := b.UnmarshalProto(data); err != nil || !reflect.DeepEqual(b, condorcet.Ballot{2, 0, 1}) {
+		t.Errorf("decoded ballot is %v (%v)", b, err)
+	}
+
+	if _, err := (condorcet.Ballot{-1}).MarshalProto(); err == nil {
+		t.Error("negative candidate encoded")
+	}
+	if err := b.UnmarshalProto([]byte{0x0a, 5, 1}); err == nil {
+		t.Error("truncated message decoded")
+	}
+}
+
+// TestElection_MarshalProto checks the round trip of an election and of a result.
+func TestElection_MarshalProto(t *testing.T) {
+	for _, opts := range [][]condorcet.Option{nil, {condorcet.Exact()}} {
+		e, _ := condorcet.NewNamed([]string{"alice", "", "carol"}, opts...)
+		e.VoteN(3, 0, 1, 2)
+		e.VoteWeighted(2, 2, 0, 1)
+
+		data, err := e.MarshalProto()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var resumed condorcet.Election
+		if err := resumed.UnmarshalProto(data); err != nil {
+			t.Fatal(err)
+		}
+		if !resumed.Result().Equal(e.Result()) || resumed.NumBallots() != 4 || resumed.Name(2) != "carol" {
+			t.Error("resumed election differs from the original one")
+		}
+	}
+
+	e, _ := condorcet.New(3, condorcet.Describe(condorcet.Metadata{Party: "green", Extra: map[string]string{"age": "42"}}))
+	e.Vote(2, 0, 1)
+	data, err := e.Result().Withdraw(2).MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r condorcet.Result
+	if err := r.UnmarshalProto(data); err != nil {
+		t.Fatal(err)
+	}
+	if w, ok := r.Winner(); !ok || w != 0 || len(r.Exclusions()) != 1 {
+		t.Errorf("winner is (%d, %v) with exclusions %v", w, ok, r.Exclusions())
+	}
+	if m := r.Metadata(0); m.Party != "green" || m.Extra["age"] != "42" {
+		t.Errorf("metadata is %+v", m)
+	}
+
+	// the encoded winner is the last field: 0x18 0
+	data[len(data)-1] = 1
+	if err := r.UnmarshalProto(data); err == nil {
+		t.Error("inconsistent winner accepted")
+	}
+}
+
+// TestResult_UnmarshalProto_noVote decodes an election without vote as a result.
+func TestResult_UnmarshalProto_noVote(t *testing.T) {
+	e, _ := condorcet.New(3)
+	data, err := e.MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Result message whose election, field 1, has no pairwise matrix
+	data = append([]byte{1<<3 | 2, byte(len(data))}, data...)
+
+	var r condorcet.Result
+	if err := r.UnmarshalProto(data); err != nil {
+		t.Fatal(err)
+	}
+	if _, exist := r.Winner(); exist || r.NumVoters() != 0 {
+		t.Error("winner without vote")
+	}
+}
@@ -0,0 +1,68 @@
+package condorcet
+
+import "time"
+
+// VoteProvisional stores a provisional ballot.
+// It is not tallied until it is accepted (see Accept).
+// It returns the identifier of the ballot.
+//
+// Like Vote, it returns false if the ballot is invalid.
+func (e *Election) VoteProvisional(ballot ...int) (id int, ok bool) {
+	ballot, ok = e.accept(ballot)
+	if !ok {
+		return 0, false
+	}
+
+	if e.provisional == nil {
+		e.provisional = make(map[int][]int)
+	}
+	id = e.nextID
+	e.nextID++
+	e.provisional[id] = append([]int(nil), ballot...)
+	return id, true
+}
+
+// NumProvisional returns the number of provisional ballots
+// neither accepted nor discarded yet.
+func (e *Election) NumProvisional() int { return len(e.provisional) }
+
+// Accept tallies the provisional ballot.
+// It returns false if there is no such pending ballot,
+// if the tally would overflow (see ErrOverflow)
+// or if it cannot be written to the journal.
+func (e *Election) Accept(id int) bool {
+	ballot, ok := e.provisional[id]
+	if !ok || e.overflows(1) != nil {
+		return false
+	}
+	if e.record(time.Time{}, ballot, 1) != nil {
+		return false
+	}
+	delete(e.provisional, id)
+	e.cast(ballot, 1)
+	e.publish()
+	return true
+}
+
+// Discard drops the provisional ballot.
+// It returns false if there is no such pending ballot.
+func (e *Election) Discard(id int) bool {
+	if _, ok := e.provisional[id]; !ok {
+		return false
+	}
+	delete(e.provisional, id)
+	return true
+}
+
+// WithProvisional returns a projection of the result
+// as if all pending provisional ballots were accepted.
+// The result itself only contains the certified tally.
+func (r Result) WithProvisional() Result {
+	cp := r.e.clone()
+	for _, ballot := range cp.provisional {
+		cp.cast(ballot, 1)
+	}
+	cp.provisional = nil
+	cp.sync()
+	return Result{cp}
+}
@@ -0,0 +1,61 @@
+package condorcet_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestElection_VoteProvisional checks the lifecycle of provisional ballots.
+func TestElection_VoteProvisional(t *testing.T) {
+	e, _ := condorcet.New(3)
+	e.Vote(0, 1, 2)
+
+	if _, ok := e.VoteProvisional(0, 1); ok {
+		t.Error("invalid provisional ballot accepted")
+	}
+	a, _ := e.VoteProvisional(1, 0, 2)
+	b, _ := e.VoteProvisional(1, 2, 0)
+	if e.NumProvisional() != 2 {
+		t.Errorf("%d provisional ballots instead of 2", e.NumProvisional())
+	}
+
+	r := e.Result()
+	if r.NumVoters() != 1 {
+		t.Errorf("provisional ballots are tallied: %d voters", r.NumVoters())
+	}
+	projection := r.WithProvisional()
+	if projection.NumVoters() != 3 {
+		t.Errorf("projection has %d voters instead of 3", projection.NumVoters())
+	}
+	if w, exist := projection.Winner(); !exist || w != 1 {
+		t.Errorf("unexpected projected winner (%d, %v)", w, exist)
+	}
+	if r.NumVoters() != 1 {
+		t.Error("projection modified the result")
+	}
+
+	if !e.Accept(a) || !e.Discard(b) {
+		t.Fatal("cannot accept or discard pending ballots")
+	}
+	if e.Accept(a) || e.Discard(b) {
+		t.Error("ballot accepted or discarded twice")
+	}
+	if e.NumVoters() != 2 || e.NumProvisional() != 0 {
+		t.Errorf("unexpected state: %d voters, %d provisional", e.NumVoters(), e.NumProvisional())
+	}
+}
+
+// TestElection_AcceptOverflow checks that a provisional ballot is not accepted beyond the limit of the counters.
+func TestElection_AcceptOverflow(t *testing.T) {
+	e, _ := condorcet.New(3, condorcet.TallyWidth(32))
+	id, _ := e.VoteProvisional(0, 1, 2)
+	e.VoteN(math.MaxUint32, 1, 0, 2)
+	if e.Accept(id) {
+		t.Error("provisional ballot accepted beyond the limit")
+	}
+	if e.NumVoters() != math.MaxUint32 || e.NumProvisional() != 1 {
+		t.Errorf("%d voters, %d provisional ballots", e.NumVoters(), e.NumProvisional())
+	}
+}
@@ -0,0 +1,109 @@
+package condorcet
+
+import (
+	"errors"
+	"time"
+)
+
+// VoteRanked registers a ballot with ties.
+// Each group is a set of equally prefered candidates:
+// first group is prefered to the second one, and so on.
+//
+// Groups must not be empty and must cover all the candidates,
+// or some of them if truncated ballots are allowed (see AllowTruncation).
+// Ballots with ties cannot be stored in an exact profile (see Exact)
+// and are not sanitized: they are rejected by elections with a profile or sanitizers.
+// A ballot without ties is registered like Vote.
+// Otherwise the ballot is ignored and false is returned.
+func (e *Election) VoteRanked(groups ...[]int) bool { return e.VoteRankedN(1, groups...) }
+
+// VoteRankedN registers count identical ballots with ties, like VoteRanked,
+// e.g. from aggregated data: the ballot is validated once and counted count times.
+// It returns false if count is zero or if the tally would overflow (see ErrOverflow).
+func (e *Election) VoteRankedN(count uint, groups ...[]int) bool {
+	var ballot []int
+	tied := false
+	for _, g := range groups {
+		ballot = append(ballot, g...)
+		tied = tied || len(g) != 1
+	}
+	if !tied {
+		return e.VoteN(count, ballot...)
+	}
+
+	err := e.voteRanked(groups, count)
+	e.notify(VoteEvent{Ballot: ballot, Groups: groups, Count: int(count), Err: err})
+	return err == nil
+}
+
+// voteRanked implements VoteRankedN for a ballot with ties.
+func (e *Election) voteRanked(groups [][]int, count uint) error {
+	if e.exact || len(e.sanitizers) > 0 {
+		return errors.New("ballot with ties needs an election without profile nor sanitizers")
+	}
+	if count == 0 {
+		return errors.New("ballot counted zero times")
+	}
+	if err := e.overflows(count); err != nil {
+		return err
+	}
+	if !e.validRanked(groups) {
+		return errors.New("invalid ballot with ties")
+	}
+	if err := e.recordRanked(time.Time{}, groups, int(count)); err != nil {
+		return err
+	}
+
+	e.castRanked(groups, int(count))
+	return nil
+}
+
+// validRanked checks that the groups form a valid ballot with ties.
+func (e *Election) validRanked(groups [][]int) bool {
+	var ballot []int
+	for _, g := range groups {
+		if len(g) == 0 {
+			return false
+		}
+		ballot = append(ballot, g...)
+	}
+	return e.valid(ballot)
+}
+
+// castRanked counts the valid ballot with ties count times in the sum matrix.
+// Candidates missing from a truncated ballot are tied last.
+func (e *Election) castRanked(groups [][]int, count int) {
+	if !e.initialized() {
+		e.init()
+	}
+	e.ballots += count
+	e.voters += count
+	e.ties = true
+	if m, ok := e.m.(*sparseMatrix); ok {
+		m.addRanked(groups, count)
+		return
+	}
+	e.densify()
+
+	ranked := make([]bool, e.num())
+	for k, g := range groups {
+		for _, i := range g {
+			ranked[i] = true
+			// candidate i is prefered to the candidates of the next groups
+			for _, next := range groups[k+1:] {
+				for _, j := range next {
+					e.m.add(i, j, count)
+				}
+			}
+		}
+	}
+	for _, g := range groups {
+		for _, i := range g {
+			for j := range ranked {
+				if !ranked[j] {
+					e.m.add(i, j, count)
+				}
+			}
+		}
+	}
+}
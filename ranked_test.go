@@ -0,0 +1,87 @@
+package condorcet_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestElection_VoteRanked checks that tied candidates are not compared.
+func TestElection_VoteRanked(t *testing.T) {
+	var journal bytes.Buffer
+	e, _ := condorcet.New(3, condorcet.Journal(&journal))
+	invalid := [][][]int{
+		{{0, 2}},          // missing candidate
+		{{0, 2}, {}, {1}}, // empty group
+		{{0, 2}, {1, 2}},  // duplicate candidate
+		{{0, 3}, {1}},     // out of range
+	}
+	for _, groups := range invalid {
+		if e.VoteRanked(groups...) {
+			t.Errorf("invalid ballot %v accepted", groups)
+		}
+	}
+	if !e.VoteRanked([]int{0, 2}, []int{1}) || !e.VoteRanked([]int{2}, []int{0}, []int{1}) {
+		t.Fatal("valid ballot rejected")
+	}
+	if n := e.NumVoters(); n != 2 {
+		t.Errorf("%d voters instead of 2", n)
+	}
+
+	// 2 beats 0 by 1 to 0 and 1 by 2 to 0
+	r := e.Result()
+	m, _ := r.Matchup(2, 0)
+	if m.ForA != 1 || m.ForB != 0 {
+		t.Errorf("2 against 0 is %d to %d instead of 1 to 0", m.ForA, m.ForB)
+	}
+	if w, ok := r.Winner(); !ok || w != 2 {
+		t.Errorf("winner is (%d, %v) instead of 2", w, ok)
+	}
+	if err := r.Verify(); err != nil {
+		t.Errorf("unexpected verification error: %v", err)
+	}
+
+	// replay the journal
+	replayed, _ := condorcet.New(3)
+	if _, err := replayed.Replay(&journal); err != nil {
+		t.Fatal(err)
+	}
+	if !replayed.Result().Equal(r) {
+		t.Error("replayed tally differs from the original one")
+	}
+
+	// ties cannot be stored in a profile
+	exact, _ := condorcet.New(3, condorcet.Exact())
+	if exact.VoteRanked([]int{0, 2}, []int{1}) {
+		t.Error("ballot with ties accepted in an exact profile")
+	}
+	if !exact.VoteRanked([]int{0}, []int{2}, []int{1}) {
+		t.Error("ballot without ties rejected in an exact profile")
+	}
+}
+
+// TestElection_VoteRankedN checks that counted ballots with ties tally like repeated ones.
+func TestElection_VoteRankedN(t *testing.T) {
+	e, _ := condorcet.New(3)
+	want, _ := condorcet.New(3)
+	if e.VoteRankedN(0, []int{0, 2}, []int{1}) {
+		t.Error("ballot counted zero times")
+	}
+	if !e.VoteRankedN(3, []int{0, 2}, []int{1}) || !e.VoteRankedN(2, []int{1}, []int{0}, []int{2}) {
+		t.Fatal("valid ballot rejected")
+	}
+	for k := 0; k < 3; k++ {
+		want.VoteRanked([]int{0, 2}, []int{1})
+	}
+	want.VoteN(2, 1, 0, 2)
+	if !e.Result().Equal(want.Result()) {
+		t.Error("counted ballots differ from repeated ones")
+	}
+
+	narrow, _ := condorcet.New(3, condorcet.TallyWidth(32))
+	if narrow.VoteRankedN(math.MaxUint32+1, []int{0, 2}, []int{1}) {
+		t.Error("overflowing ballot accepted")
+	}
+}
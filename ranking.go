@@ -0,0 +1,78 @@
+package condorcet
+
+import "sort"
+
+// Ranking is an ordering of the candidates which allows ties.
+// It is the representation of the rankings produced by the package.
+//
+// Each item is a group of tied candidates, in increasing order of index.
+// Groups are sorted from the best to the worst.
+type Ranking [][]int
+
+// Position returns the index of the group of the candidate
+// or -1 if the candidate is not ranked.
+func (rk Ranking) Position(candidate int) int {
+	for p, group := range rk {
+		for _, c := range group {
+			if c == candidate {
+				return p
+			}
+		}
+	}
+	return -1
+}
+
+// Strict returns the candidates from the best to the worst
+// if there is no tie in the ranking.
+// Otherwise it returns false.
+func (rk Ranking) Strict() ([]int, bool) {
+	order := make([]int, 0, len(rk))
+	for _, group := range rk {
+		if len(group) != 1 {
+			return nil, false
+		}
+		order = append(order, group[0])
+	}
+	return order, true
+}
+
+// Ranking returns the complete ranking of the candidates, from the first place to the last,
+// for display. The Condorcet winner, if any, is first and
+// the other places are completed by the Schulze method (see Schulze).
+//
+// Candidates excluded by the eligibility hook are not ranked,
+// nor is "none of the above", which is no place to display: see NOTAWins.
+func (r Result) Ranking() Ranking {
+	_, _, rk := r.Schulze()
+	if _, ok := r.e.NOTA(); !ok {
+		return rk
+	}
+	var ranked Ranking
+	for _, group := range rk {
+		if group = r.withoutNOTA(group); len(group) > 0 {
+			ranked = append(ranked, group)
+		}
+	}
+	return ranked
+}
+
+// rankByScore returns the ranking of the candidates by decreasing score,
+// scores being indexed by candidate.
+// Candidates with equal scores are tied.
+func rankByScore(candidates []int, scores []int) Ranking {
+	order := append([]int(nil), candidates...)
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+
+	var rk Ranking
+	for k, c := range order {
+		if k > 0 && scores[c] == scores[order[k-1]] {
+			rk[len(rk)-1] = append(rk[len(rk)-1], c)
+			continue
+		}
+		rk = append(rk, []int{c})
+	}
+	for _, group := range rk {
+		sort.Ints(group)
+	}
+	return rk
+}
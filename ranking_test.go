@@ -0,0 +1,44 @@
+package condorcet_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestRanking asserts the behaviour of Position and Strict with and without ties.
+func TestRanking(t *testing.T) {
+	rk := condorcet.Ranking{{2}, {0, 3}, {1}}
+	if p := rk.Position(3); p != 1 {
+		t.Errorf("candidate 3 is at position %d instead of 1", p)
+	}
+	if p := rk.Position(4); p != -1 {
+		t.Errorf("unranked candidate is at position %d", p)
+	}
+	if _, ok := rk.Strict(); ok {
+		t.Error("ranking with ties is strict")
+	}
+
+	rk = condorcet.Ranking{{2}, {0}, {1}}
+	if order, ok := rk.Strict(); !ok || !reflect.DeepEqual(order, []int{2, 0, 1}) {
+		t.Errorf("unexpected strict order %v", order)
+	}
+}
+
+// TestResult_Ranking checks the complete rankings of the testcases.
+func TestResult_Ranking(t *testing.T) {
+	testcases := []struct {
+		label string
+		rk    condorcet.Ranking
+	}{
+		{"Condorcet's example", condorcet.Ranking{{2}, {1}, {0}}},
+		{"Schulze's example", condorcet.Ranking{{4}, {0}, {2}, {1}, {3}}},
+		{"no vote", condorcet.Ranking{{0, 1, 2, 3, 4, 5}}},
+	}
+	for _, tc := range testcases {
+		if rk := result(t, tc.label).Ranking(); !reflect.DeepEqual(rk, tc.rk) {
+			t.Errorf("%s: ranking %v instead of %v", tc.label, rk, tc.rk)
+		}
+	}
+}
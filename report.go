@@ -0,0 +1,129 @@
+package condorcet
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Report writers stream their output to the writer row by row,
+// so that memory stays bounded whatever the size of the election.
+// Wrap them with Gzip for compressed output.
+
+// Gzip runs the report writer through a gzip compressor writing to w:
+//
+//	err := condorcet.Gzip(f, r.WriteCVRCSV)
+func Gzip(w io.Writer, report func(io.Writer) error) error {
+	zw := gzip.NewWriter(w)
+	if err := report(zw); err != nil {
+		zw.Close()
+		return err
+	}
+	return zw.Close()
+}
+
+// WriteText writes a plain text report of the result:
+// the number of candidates and voters, the winner, the description of the candidates
+// (see Describe) and the pairwise table.
+// Candidates are designated by their names (see Name).
+func (r Result) WriteText(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "candidates: %d\n", r.e.num())
+	fmt.Fprintf(bw, "voters: %d\n", r.NumVoters())
+	if winner, exist := r.Winner(); exist {
+		fmt.Fprintf(bw, "winner: %s\n", r.Name(winner))
+	} else {
+		fmt.Fprintln(bw, "winner: none")
+	}
+	for _, x := range r.e.exclusions {
+		fmt.Fprintf(bw, "excluded: %s (%s)\n", r.Name(x.Candidate), x.Reason)
+	}
+	for c, m := range r.e.meta {
+		fmt.Fprintf(bw, "candidate %s: %s", r.Name(c), m.DisplayName)
+		if m.Party != "" {
+			fmt.Fprintf(bw, " (%s)", m.Party)
+		}
+		if m.URL != "" {
+			fmt.Fprintf(bw, " %s", m.URL)
+		}
+		bw.WriteByte('\n')
+	}
+
+	// pairwise table: row i, column j is the support of i against j
+	width := len(strconv.Itoa(r.NumVoters()))
+	for c := 0; c < r.e.num(); c++ {
+		if l := utf8.RuneCountInString(r.Name(c)); l > width {
+			width = l
+		}
+	}
+	fmt.Fprintf(bw, "\n%*s", width, "")
+	for j := 0; j < r.e.num(); j++ {
+		fmt.Fprintf(bw, " %*s", width, r.Name(j))
+	}
+	bw.WriteByte('\n')
+	for i := 0; i < r.e.num(); i++ {
+		fmt.Fprintf(bw, "%*s", width, r.Name(i))
+		for j := 0; j < r.e.num(); j++ {
+			if i == j {
+				fmt.Fprintf(bw, " %*s", width, "-")
+				continue
+			}
+			fmt.Fprintf(bw, " %*d", width, r.e.m.at(i, j))
+		}
+		if _, err := bw.WriteString("\n"); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// WritePairwiseCSV writes the pairwise matrix as CSV, one row per candidate:
+// column j of row i is the number of voters preferring i to j.
+// The diagonal is empty.
+func (r Result) WritePairwiseCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	record := make([]string, r.e.num())
+	for i := 0; i < r.e.num(); i++ {
+		for j := range record {
+			record[j] = ""
+			if i != j {
+				record[j] = strconv.Itoa(r.e.m.at(i, j))
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteDOT writes the graph of the pairwise defeats in the Graphviz DOT language:
+// an edge goes from the winner to the loser of each defeat and is labelled with its margin.
+// Ties are drawn as dashed edges without direction.
+// Nodes are labelled with the names of the candidates (see Name).
+// Candidates excluded by the eligibility hook are not drawn.
+func (r Result) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("digraph defeats {\n")
+	candidates := r.e.candidates()
+	for _, c := range candidates {
+		fmt.Fprintf(bw, "  %d [label=%s];\n", c, strconv.Quote(r.Name(c)))
+	}
+	for _, d := range r.defeats(candidates) {
+		fmt.Fprintf(bw, "  %d -> %d [label=\"%d\"];\n", d.Winner, d.Loser, d.Margin)
+	}
+	for k, a := range candidates {
+		for _, b := range candidates[k+1:] {
+			if r.Margin(a, b) == 0 {
+				fmt.Fprintf(bw, "  %d -> %d [label=\"0\", dir=none, style=dashed];\n", a, b)
+			}
+		}
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
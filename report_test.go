@@ -0,0 +1,110 @@
+package condorcet_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_WriteText checks the text report of Condorcet's example.
+func TestResult_WriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := result(t, "Condorcet's example").WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `candidates: 3
+voters: 60
+winner: 2
+
+    0  1  2
+ 0  - 25 23
+ 1 35  - 19
+ 2 37 41  -
+`
+	if buf.String() != want {
+		t.Errorf("unexpected report:\n%s", buf.String())
+	}
+}
+
+// TestResult_WriteTextNamed checks that the text report of a named election uses the names.
+func TestResult_WriteTextNamed(t *testing.T) {
+	e, _ := condorcet.NewNamed([]string{"Alice", "Bob"})
+	e.Vote(1, 0)
+	var buf bytes.Buffer
+	if err := e.Result().WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `candidates: 2
+voters: 1
+winner: Bob
+
+      Alice   Bob
+Alice     -     0
+  Bob     1     -
+`
+	if buf.String() != want {
+		t.Errorf("unexpected report:\n%s", buf.String())
+	}
+}
+
+// TestGzip checks that a compressed report decompresses to the plain one.
+func TestGzip(t *testing.T) {
+	r := result(t, "4 candidates")
+
+	var plain, compressed bytes.Buffer
+	if err := r.WritePairwiseCSV(&plain); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(plain.String(), ",83,58,32\n") {
+		t.Errorf("unexpected CSV:\n%s", plain.String())
+	}
+
+	if err := condorcet.Gzip(&compressed, r.WritePairwiseCSV); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := gzip.NewReader(&compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decompressed, _ := ioutil.ReadAll(zr)
+	if string(decompressed) != plain.String() {
+		t.Errorf("decompressed report differs:\n%s", decompressed)
+	}
+}
+
+// TestResult_WriteDOT checks the defeat graph of the paradox and of a tie.
+func TestResult_WriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := result(t, "paradoxe").WriteDOT(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `digraph defeats {
+  0 [label="0"];
+  1 [label="1"];
+  2 [label="2"];
+  1 -> 2 [label="24"];
+  2 -> 0 [label="10"];
+  0 -> 1 [label="6"];
+}
+`
+	if buf.String() != want {
+		t.Errorf("unexpected graph:\n%s", buf.String())
+	}
+
+	e, _ := condorcet.NewNamed([]string{"Alice", "Bob"})
+	e.Vote(0, 1)
+	e.Vote(1, 0)
+	buf.Reset()
+	if err := e.Result().WriteDOT(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `0 [label="Alice"]`) || !strings.Contains(buf.String(), `0 -> 1 [label="0", dir=none, style=dashed]`) {
+		t.Errorf("unexpected graph:\n%s", buf.String())
+	}
+}
@@ -11,29 +11,163 @@ type Result struct {
 // If there is no winner it returns false.
 //
 // An election with no vote has no winner.
+// If "none of the above" wins, there is no valid winner (see NoneOfTheAbove).
+// Candidates excluded by the eligibility hook are ignored (see SetEligibilityHook).
 func (r Result) Winner() (w int, exist bool) {
+	r.e.do(PhaseWinner, func() { w, exist = r.winner() })
+	if nota, ok := r.e.NOTA(); ok && exist && w == nota {
+		return 0, false
+	}
+	return
+}
+
+// winner implements Winner.
+func (r Result) winner() (w int, exist bool) {
+	// first eligible candidate
+	for w < r.e.num() && !r.e.eligible(w) {
+		w++
+	}
+	if w == r.e.num() {
+		return 0, false
+	}
+
 	// find the winner
-	for i := 1; i < r.e.num(); i++ {
+	for i := w + 1; i < r.e.num(); i++ {
+		if !r.e.eligible(i) {
+			continue
+		}
+
 		// i is the challenger of w
-		if r.e.m[r.e.index(w, i)] < r.e.m[r.e.index(i, w)] {
+		if r.e.m.at(w, i) < r.e.m.at(i, w) {
 			w = i // i beats w
 		}
 	}
 
 	// is w really a winner?
 	for i := 0; i < r.e.num(); i++ {
-		if w == i {
+		if w == i || !r.e.eligible(i) {
 			continue
 		}
 
 		// i is the challenger of w
-		if r.e.m[r.e.index(w, i)] <= r.e.m[r.e.index(i, w)] {
-			return // w fails to beat i: not a winner finally
+		if r.e.m.at(w, i) <= r.e.m.at(i, w) {
+			return 0, false // w fails to beat i: not a winner finally
+		}
+	}
+
+	return w, r.NumVoters() > 0
+}
+
+// Winners returns the winner, or the co-winners if the top candidates tie:
+// the candidates who tie with each other and beat every other candidate,
+// in increasing order. It is the Smith set if its candidates all tie (see Smith).
+//
+// It returns nil if there is a majority cycle among the top candidates,
+// i.e. a genuine Condorcet paradox, or if there is no vote.
+// "None of the above" is never returned (see NoneOfTheAbove).
+// Candidates excluded by the eligibility hook are ignored (see SetEligibilityHook).
+func (r Result) Winners() []int {
+	if r.NumVoters() == 0 {
+		return nil
+	}
+
+	smith := r.Smith()
+	for _, a := range smith {
+		for _, b := range smith {
+			if r.Margin(a, b) != 0 {
+				return nil
+			}
+		}
+	}
+
+	var winners []int
+	for _, c := range smith {
+		if nota, ok := r.e.NOTA(); !ok || c != nota {
+			winners = append(winners, c)
+		}
+	}
+	return winners
+}
+
+// WeakWinners returns the candidates who are beaten by no other candidate,
+// in increasing order: they beat or tie every other candidate.
+// The winner, if any, is the only weak winner.
+//
+// An election with no vote has no weak winner.
+// "None of the above" is never returned (see NoneOfTheAbove).
+// Candidates excluded by the eligibility hook are ignored (see SetEligibilityHook).
+func (r Result) WeakWinners() []int {
+	if r.NumVoters() == 0 {
+		return nil
+	}
+
+	nota, hasNOTA := r.e.NOTA()
+	candidates := r.e.candidates()
+	var winners []int
+	for _, i := range candidates {
+		unbeaten := true
+		for _, j := range candidates {
+			if i != j && !r.beatsOrTies(i, j) {
+				unbeaten = false
+				break
+			}
+		}
+		if unbeaten && !(hasNOTA && i == nota) {
+			winners = append(winners, i)
 		}
 	}
+	return winners
+}
+
+// MarginOfVictory returns the smallest margin of the winner over another candidate (see Margin).
+// It is the minimum number of additional ballots which would prevent the winner from winning:
+// ranking the closest challenger first and the winner last.
+// Changing existing ballots requires half as many ballots, rounded up,
+// since each change moves the margin by two.
+// It is the key input of recount thresholds and risk-limiting audits.
+//
+// If there is no winner it returns false.
+func (r Result) MarginOfVictory() (int, bool) {
+	w, exist := r.Winner()
+	if !exist {
+		return 0, false
+	}
 
-	return w, true
+	margin := -1
+	for _, c := range r.e.candidates() {
+		if m := r.Margin(w, c); c != w && (margin < 0 || m < margin) {
+			margin = m
+		}
+	}
+	if margin < 0 {
+		return 0, false // no challenger
+	}
+	return margin, true
 }
 
-// NumVoters returns the number of voters.
+// NumVoters returns the number of voters, i.e. the total weight of the ballots.
 func (r Result) NumVoters() int { return r.e.NumVoters() }
+
+// NumBallots returns the number of ballots.
+func (r Result) NumBallots() int { return r.e.NumBallots() }
+
+// NumCandidates returns the number of candidates.
+func (r Result) NumCandidates() int { return r.e.num() }
+
+// Deficit returns how many votes the candidate is short of the winner
+// in their head-to-head contest: the support of the winner against the candidate
+// minus the support of the candidate against the winner.
+// The deficit of the winner is 0.
+// Excluded candidates have no deficit (see SetEligibilityHook).
+//
+// If there is no winner it returns false.
+func (r Result) Deficit(candidate int) (int, bool) {
+	w, exist := r.Winner()
+	if !exist || candidate < 0 || candidate >= r.e.num() || !r.e.eligible(candidate) {
+		return 0, false
+	}
+	if candidate == w {
+		return 0, true
+	}
+	return r.e.m.at(w, candidate) - r.e.m.at(candidate, w), true
+}
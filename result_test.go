@@ -0,0 +1,163 @@
+package condorcet_test
+
+import (
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// result returns the result of the testcase with the given label.
+func result(t *testing.T, label string, opts ...condorcet.Option) condorcet.Result {
+	t.Helper()
+	for _, tc := range testcases {
+		if tc.label != label {
+			continue
+		}
+
+		e, err := condorcet.New(tc.num, opts...)
+		if err != nil {
+			t.Fatalf("testcase %q is invalid: %v", tc.label, err)
+		}
+		for _, ballot := range tc.ballots {
+			e.VoteN(uint(ballot[0]), ballot[1:]...)
+		}
+		return e.Result()
+	}
+	t.Fatalf("unknown testcase %q", label)
+	return condorcet.Result{}
+}
+
+// TestResult_Deficit checks the deficits in Condorcet's example.
+func TestResult_Deficit(t *testing.T) {
+	r := result(t, "Condorcet's example")
+	for candidate, want := range []int{14, 22, 0} {
+		if d, ok := r.Deficit(candidate); !ok || d != want {
+			t.Errorf("deficit of %d is (%d, %v) instead of %d", candidate, d, ok, want)
+		}
+	}
+
+	if _, ok := result(t, "paradoxe").Deficit(0); ok {
+		t.Error("deficit reported without a winner")
+	}
+}
+
+// TestResult_Winners checks that ties at the top are told apart from cycles.
+func TestResult_Winners(t *testing.T) {
+	if w := result(t, "Condorcet's example").Winners(); len(w) != 1 || w[0] != 2 {
+		t.Errorf("winners are %v instead of the winner", w)
+	}
+	if w := result(t, "paradoxe").Winners(); w != nil {
+		t.Errorf("winners %v in a cycle", w)
+	}
+	if w := result(t, "no vote").Winners(); w != nil {
+		t.Errorf("winners %v without vote", w)
+	}
+
+	// 0 and 1 tie, and both beat 2
+	e, _ := condorcet.New(3)
+	e.Vote(0, 1, 2)
+	e.Vote(1, 0, 2)
+	if w := e.Result().Winners(); len(w) != 2 || w[0] != 0 || w[1] != 1 {
+		t.Errorf("winners are %v instead of [0 1]", w)
+	}
+}
+
+// TestResult_WeakWinners checks the candidates beaten by no other candidate.
+func TestResult_WeakWinners(t *testing.T) {
+	if w := result(t, "Condorcet's example").WeakWinners(); len(w) != 1 || w[0] != 2 {
+		t.Errorf("weak winners are %v instead of the winner", w)
+	}
+	if w := result(t, "paradoxe").WeakWinners(); len(w) != 0 {
+		t.Errorf("weak winners %v in a cycle", w)
+	}
+	if w := result(t, "no vote").WeakWinners(); len(w) != 0 {
+		t.Errorf("weak winners %v without vote", w)
+	}
+
+	// 0 and 1 tie, and both beat 2
+	e, _ := condorcet.New(3)
+	e.Vote(0, 1, 2)
+	e.Vote(1, 0, 2)
+	if w := e.Result().WeakWinners(); len(w) != 2 || w[0] != 0 || w[1] != 1 {
+		t.Errorf("weak winners are %v instead of [0 1]", w)
+	}
+}
+
+// TestResult_MarginOfVictory checks the margin of the winner of the testcases.
+func TestResult_MarginOfVictory(t *testing.T) {
+	// 2 beats 0 by 37 to 23 and 1 by 41 to 19
+	if m, ok := result(t, "Condorcet's example").MarginOfVictory(); !ok || m != 14 {
+		t.Errorf("margin of victory is (%d, %v) instead of 14", m, ok)
+	}
+	if _, ok := result(t, "paradoxe").MarginOfVictory(); ok {
+		t.Error("margin of victory without a winner")
+	}
+}
+
+// TestResult_WinnerWithThreshold checks supermajorities against a status quo in Condorcet's example.
+func TestResult_WinnerWithThreshold(t *testing.T) {
+	r := result(t, "Condorcet's example")
+
+	// the winner 2 beats 0 by 37 to 23: 61% of the voters
+	testcases := []struct {
+		threshold condorcet.Threshold
+		met       bool
+	}{
+		{condorcet.Threshold{StatusQuo: 0, Num: 1, Den: 2}, true},
+		{condorcet.Threshold{StatusQuo: 0, Num: 3, Den: 5}, true},
+		{condorcet.Threshold{StatusQuo: 0, Num: 2, Den: 3}, false},
+		{condorcet.Threshold{StatusQuo: 2, Num: 1, Den: 1}, true},
+	}
+	for _, tc := range testcases {
+		w, exist, met, err := r.WinnerWithThreshold(tc.threshold)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !exist || w != 2 || met != tc.met {
+			t.Errorf("threshold %+v: (%d, %v, %v) instead of met=%v", tc.threshold, w, exist, met, tc.met)
+		}
+	}
+
+	if _, _, _, err := r.WinnerWithThreshold(condorcet.Threshold{StatusQuo: 0, Num: 3, Den: 2}); err == nil {
+		t.Error("threshold above 1 accepted")
+	}
+}
+
+// TestResult_Stability checks the stability of a ranking in Condorcet's example.
+func TestResult_Stability(t *testing.T) {
+	r := result(t, "Condorcet's example")
+
+	// 2 beats 0 by 37 to 23 and 1 beats 0 by 35 to 25
+	stability, err := r.Stability(condorcet.Ranking{{2}, {0}, {1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stability) != 2 || stability[0] != 8 || stability[1] != 0 {
+		t.Errorf("unexpected stability %v", stability)
+	}
+
+	stability, _ = r.Stability(condorcet.Ranking{{2}, {1}, {0}})
+	if stability[0] != 12 || stability[1] != 6 {
+		t.Errorf("unexpected stability %v", stability)
+	}
+
+	if _, err := r.Stability(condorcet.Ranking{{3}}); err == nil {
+		t.Error("ranking with an unknown candidate accepted")
+	}
+}
+
+// TestResult_Verify checks that valid results pass the verification.
+func TestResult_Verify(t *testing.T) {
+	for _, tc := range testcases {
+		if err := result(t, tc.label).Verify(); err != nil {
+			t.Errorf("testcase %q: %v", tc.label, err)
+		}
+	}
+
+	e, _ := condorcet.New(4, condorcet.Exact())
+	e.Vote(3, 1, 0, 2)
+	e.Vote(1, 0, 2, 3)
+	if err := e.Result().Verify(); err != nil {
+		t.Errorf("exact profile: %v", err)
+	}
+}
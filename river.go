@@ -0,0 +1,63 @@
+package condorcet
+
+// River returns the outcome of the River method:
+// defeats are considered by decreasing margin and locked in,
+// unless the loser already has a locked defeat or the defeat would close a cycle.
+// The locked defeats form a tree whose root wins.
+//
+// It returns the winner, false if the locked defeats do not connect all the candidates,
+// and the locked defeats, in the order they were locked.
+// Defeats with equal margins are considered by increasing winner, then loser.
+// Candidates excluded by the eligibility hook do not run.
+// If "none of the above" is among the roots, there is no valid winner (see NoneOfTheAbove).
+func (r Result) River() (w int, unique bool, tree []Defeat) {
+	var roots []int
+	r.e.do(PhaseRiver, func() { roots, tree = r.river() })
+	w, unique = r.elect(roots)
+	return w, unique, tree
+}
+
+// river returns the roots of the River forest and its defeats.
+func (r Result) river() (roots []int, tree []Defeat) {
+	candidates := r.e.candidates()
+	parent := make([]int, r.e.num()) // winner of the locked defeat of each candidate, -1 if none
+	for c := range parent {
+		parent[c] = -1
+	}
+
+	for _, d := range r.defeats(candidates) {
+		if parent[d.Loser] != -1 {
+			continue // second defeat of the loser
+		}
+		// is the loser an ancestor of the winner?
+		cycle := false
+		for a := d.Winner; a != -1; a = parent[a] {
+			if a == d.Loser {
+				cycle = true
+				break
+			}
+		}
+		if cycle {
+			continue
+		}
+		parent[d.Loser] = d.Winner
+		tree = append(tree, d)
+	}
+
+	for _, c := range candidates {
+		if parent[c] == -1 {
+			roots = append(roots, c)
+		}
+	}
+	return roots, tree
+}
+
+func init() {
+	completions = append(completions, completion{
+		name: "River",
+		winners: func(r Result) []int {
+			roots, _ := r.river()
+			return roots
+		},
+	})
+}
@@ -0,0 +1,40 @@
+package condorcet_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_River checks the River method on the testcases.
+func TestResult_River(t *testing.T) {
+	testcases := []struct {
+		label  string
+		winner int
+		unique bool
+	}{
+		{"Condorcet's example", 2, true},
+		{"4 candidates", 3, true},
+		{"paradoxe", 1, true},
+		{"Schulze's example", 0, true}, // like Ranked Pairs, unlike Schulze
+		{"no vote", 0, false},
+	}
+
+	for _, tc := range testcases {
+		w, unique, tree := result(t, tc.label).River()
+		if w != tc.winner || unique != tc.unique {
+			t.Errorf("%s: winner (%d, %v) instead of (%d, %v)", tc.label, w, unique, tc.winner, tc.unique)
+		}
+		if unique && len(tree) != result(t, tc.label).NumCandidates()-1 {
+			t.Errorf("%s: tree %v does not span the candidates", tc.label, tree)
+		}
+	}
+
+	// the defeat of 2 by 0 closes the cycle
+	_, _, tree := result(t, "paradoxe").River()
+	want := []condorcet.Defeat{{Winner: 1, Loser: 2, Margin: 24}, {Winner: 2, Loser: 0, Margin: 10}}
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("tree is %v instead of %v", tree, want)
+	}
+}
@@ -0,0 +1,117 @@
+package condorcet
+
+import "sync"
+
+// SafeElection is an election safe for concurrent use,
+// e.g. by the handlers of a web server recording votes.
+// Calls are serialized by a mutex.
+type SafeElection struct {
+	mu sync.Mutex
+	e  *Election
+}
+
+// NewSafe returns a safe election with n candidates (see New).
+func NewSafe(n int, opts ...Option) (*SafeElection, error) {
+	e, err := New(n, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return Safe(e), nil
+}
+
+// Safe returns a safe election wrapping e.
+// The election must not be used directly afterwards.
+func Safe(e *Election) *SafeElection { return &SafeElection{e: e} }
+
+// Vote registers a ballot (see Election.Vote).
+func (s *SafeElection) Vote(ballot ...int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.e.Vote(ballot...)
+}
+
+// VoteN registers count identical ballots (see Election.VoteN).
+func (s *SafeElection) VoteN(count uint, ballot ...int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.e.VoteN(count, ballot...)
+}
+
+// VoteWeighted registers a weighted ballot (see Election.VoteWeighted).
+func (s *SafeElection) VoteWeighted(weight uint, ballot ...int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.e.VoteWeighted(weight, ballot...)
+}
+
+// VoteE registers a ballot and returns why it is invalid (see Election.VoteE).
+func (s *SafeElection) VoteE(ballot ...int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.e.VoteE(ballot...)
+}
+
+// VoteNE registers count identical ballots and returns why they are invalid (see Election.VoteNE).
+func (s *SafeElection) VoteNE(count uint, ballot ...int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.e.VoteNE(count, ballot...)
+}
+
+// VoteRanked registers a ballot with ties (see Election.VoteRanked).
+func (s *SafeElection) VoteRanked(groups ...[]int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.e.VoteRanked(groups...)
+}
+
+// VoteRankedN registers count identical ballots with ties (see Election.VoteRankedN).
+func (s *SafeElection) VoteRankedN(count uint, groups ...[]int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.e.VoteRankedN(count, groups...)
+}
+
+// VoteAll registers a batch of ballots (see Election.VoteAll).
+func (s *SafeElection) VoteAll(ballots [][]int) (accepted int, firstErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.e.VoteAll(ballots)
+}
+
+// VoteProfile registers the ballots of a profile (see Election.VoteProfile).
+func (s *SafeElection) VoteProfile(p *Profile) (accepted int, firstErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.e.VoteProfile(p)
+}
+
+// NumVoters returns the number of voters (see Election.NumVoters).
+func (s *SafeElection) NumVoters() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.e.NumVoters()
+}
+
+// NumBallots returns the number of ballots (see Election.NumBallots).
+func (s *SafeElection) NumBallots() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.e.NumBallots()
+}
+
+// Result returns a snapshot of the election (see Election.Result).
+// The result is independent of the election and can be used concurrently with it.
+func (s *SafeElection) Result() Result {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.e.Result()
+}
+
+// Do calls f with the election, e.g. to use a method without safe counterpart.
+// Other calls wait until f returns; f must not retain the election.
+func (s *SafeElection) Do(f func(e *Election)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(s.e)
+}
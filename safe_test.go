@@ -0,0 +1,38 @@
+package condorcet_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestSafeElection votes from several goroutines; run with -race.
+func TestSafeElection(t *testing.T) {
+	s, err := condorcet.NewSafe(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for k := 0; k < 100; k++ {
+				s.Vote(g%3, (g+1)%3, (g+2)%3)
+				s.Result()
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if s.NumVoters() != 800 {
+		t.Errorf("%d voters instead of 800", s.NumVoters())
+	}
+	var ballots int
+	s.Do(func(e *condorcet.Election) { ballots = e.NumBallots() })
+	if ballots != 800 {
+		t.Errorf("%d ballots instead of 800", ballots)
+	}
+}
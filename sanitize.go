@@ -0,0 +1,47 @@
+package condorcet
+
+import "errors"
+
+// Sanitizer transforms a ballot over n candidates before it is validated and tallied,
+// e.g. to apply jurisdiction-specific rules.
+// It must not modify the given ballot in place.
+// A non-nil error rejects the ballot.
+type Sanitizer func(ballot []int, n int) ([]int, error)
+
+// Sanitize makes the election run the sanitizers, in order,
+// on every ballot it receives, whatever the ingestion path.
+func Sanitize(sanitizers ...Sanitizer) Option {
+	return func(e *Election) { e.sanitizers = append(e.sanitizers, sanitizers...) }
+}
+
+// DropOutOfRange removes the entries which are not candidates of the election.
+func DropOutOfRange(ballot []int, n int) ([]int, error) {
+	kept := make([]int, 0, len(ballot))
+	for _, c := range ballot {
+		if c >= 0 && c < n {
+			kept = append(kept, c)
+		}
+	}
+	return kept, nil
+}
+
+// CollapseDuplicates keeps only the first, i.e. best, occurrence of each candidate.
+func CollapseDuplicates(ballot []int, n int) ([]int, error) {
+	kept := make([]int, 0, len(ballot))
+	seen := make(map[int]bool, len(ballot))
+	for _, c := range ballot {
+		if !seen[c] {
+			seen[c] = true
+			kept = append(kept, c)
+		}
+	}
+	return kept, nil
+}
+
+// RejectOverRanked rejects ballots with more entries than candidates.
+func RejectOverRanked(ballot []int, n int) ([]int, error) {
+	if len(ballot) > n {
+		return nil, errors.New("ballot ranks more entries than candidates")
+	}
+	return ballot, nil
+}
@@ -0,0 +1,46 @@
+package condorcet_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestSanitize checks that sanitizers are applied on every ingestion path.
+func TestSanitize(t *testing.T) {
+	e, _ := condorcet.New(
+		3,
+		condorcet.Sanitize(condorcet.DropOutOfRange, condorcet.CollapseDuplicates),
+	)
+
+	if !e.Vote(0, 7, 1, 0, 2) {
+		t.Error("sanitizable ballot rejected")
+	}
+	if e.Vote(0, 1, 1) {
+		t.Error("ballot missing a candidate after sanitization accepted")
+	}
+	if _, ok := e.VoteProvisional(2, 2, 1, 0); !ok {
+		t.Error("sanitizable provisional ballot rejected")
+	}
+
+	p := condorcet.NewProxies()
+	p.Cast("alice", 1, -1, 0, 2)
+	if _, err := p.Tally(e); err != nil {
+		t.Errorf("sanitizable proxy ballot rejected: %v", err)
+	}
+	if e.NumVoters() != 2 {
+		t.Errorf("%d voters instead of 2", e.NumVoters())
+	}
+
+	// custom rule
+	e, _ = condorcet.New(3, condorcet.Sanitize(func(ballot []int, n int) ([]int, error) {
+		if ballot[0] == 2 {
+			return nil, errors.New("candidate 2 cannot be ranked first")
+		}
+		return ballot, nil
+	}))
+	if e.Vote(2, 0, 1) {
+		t.Error("ballot rejected by a custom rule accepted")
+	}
+}
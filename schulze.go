@@ -0,0 +1,94 @@
+package condorcet
+
+// Schulze returns the outcome of the Schulze method,
+// which always has a winner, possibly tied.
+//
+// The strength of a defeat is the number of voters supporting the winner of the defeat.
+// The strength of a beatpath is the strength of its weakest defeat,
+// and i is ranked ahead of j if the strongest beatpath from i to j
+// is stronger than the strongest beatpath from j to i.
+// Candidates are then ranked by the number of candidates they are ahead of.
+//
+// It returns the winner, false if several candidates tie for first place,
+// and the full ranking.
+// Candidates excluded by the eligibility hook are not ranked.
+// If "none of the above" is ranked first, even tied, there is no valid winner (see NoneOfTheAbove).
+func (r Result) Schulze() (w int, unique bool, rk Ranking) {
+	r.e.do(PhaseSchulze, func() { rk = r.schulze() })
+	if len(rk) == 0 {
+		return 0, false, rk
+	}
+	w, unique = r.elect(rk[0])
+	return w, unique, rk
+}
+
+// schulze implements Schulze.
+func (r Result) schulze() Ranking {
+	candidates := r.e.candidates()
+	p := r.beatpaths(candidates)
+
+	scores := make([]int, r.e.num())
+	for _, i := range candidates {
+		for _, j := range candidates {
+			if i != j && p[i][j] > p[j][i] {
+				scores[i]++
+			}
+		}
+	}
+	return rankByScore(candidates, scores)
+}
+
+// beatpaths returns the strength of the strongest beatpath
+// between each pair of the candidates (Floyd-Warshall).
+func (r Result) beatpaths(candidates []int) [][]int {
+	n := r.e.num()
+	p := make([][]int, n)
+	for i := range p {
+		p[i] = make([]int, n)
+	}
+	for _, i := range candidates {
+		for _, j := range candidates {
+			if i != j && r.e.m.at(i, j) > r.e.m.at(j, i) {
+				p[i][j] = r.e.m.at(i, j)
+			}
+		}
+	}
+
+	for _, k := range candidates {
+		for _, i := range candidates {
+			if i == k {
+				continue
+			}
+			for _, j := range candidates {
+				if j == i || j == k {
+					continue
+				}
+				if s := min(p[i][k], p[k][j]); s > p[i][j] {
+					p[i][j] = s
+				}
+			}
+		}
+	}
+	return p
+}
+
+// min returns the minimum of a and b.
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func init() {
+	completions = append(completions, completion{
+		name: "Schulze",
+		winners: func(r Result) []int {
+			_, _, rk := r.Schulze()
+			if len(rk) == 0 {
+				return nil
+			}
+			return rk[0]
+		},
+	})
+}
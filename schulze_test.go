@@ -0,0 +1,45 @@
+package condorcet_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_Schulze checks the Schulze method on the testcases.
+func TestResult_Schulze(t *testing.T) {
+	testcases := []struct {
+		label   string
+		winner  int
+		unique  bool
+		ranking condorcet.Ranking
+	}{
+		{"Condorcet's example", 2, true, condorcet.Ranking{{2}, {1}, {0}}},
+		{"4 candidates", 3, true, condorcet.Ranking{{3}, {0}, {1}, {2}}},
+		{"Schulze's example", 4, true, condorcet.Ranking{{4}, {0}, {2}, {1}, {3}}},
+		{"no vote", 0, false, condorcet.Ranking{{0, 1, 2, 3, 4, 5}}},
+	}
+
+	for _, tc := range testcases {
+		w, unique, rk := result(t, tc.label).Schulze()
+		if w != tc.winner || unique != tc.unique {
+			t.Errorf("%s: winner (%d, %v) instead of (%d, %v)", tc.label, w, unique, tc.winner, tc.unique)
+		}
+		if !reflect.DeepEqual(rk, tc.ranking) {
+			t.Errorf("%s: ranking %v instead of %v", tc.label, rk, tc.ranking)
+		}
+	}
+
+	// the Schulze method resolves the cycle of the paradox
+	rep, _ := result(t, "paradoxe").CycleReport()
+	for _, b := range rep.Breaks {
+		if b.Method == "Schulze" {
+			if !reflect.DeepEqual(b.Winners, []int{1}) {
+				t.Errorf("unexpected Schulze winners %v in the cycle report", b.Winners)
+			}
+			return
+		}
+	}
+	t.Error("Schulze method missing from the cycle report")
+}
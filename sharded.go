@@ -0,0 +1,56 @@
+package condorcet
+
+import "errors"
+
+// Sharded is an election tallied in shards, for very high vote throughput:
+// each goroutine votes in its own shard, without locking,
+// and the shards are merged into the result.
+//
+//	s, _ := condorcet.NewSharded(workers, n)
+//	for w := 0; w < workers; w++ {
+//		go func(shard *condorcet.Election) { ... shard.Vote(ballot...) ... }(s.Shard(w))
+//	}
+//	// wait for the workers
+//	r, err := s.Result()
+type Sharded struct {
+	shards []*Election
+}
+
+// NewSharded returns an election with n candidates tallied in the given number of shards.
+// Every shard is created with the options (see New);
+// a journal, if any, must be safe for concurrent use.
+func NewSharded(shards, n int, opts ...Option) (*Sharded, error) {
+	if shards < 1 {
+		return nil, errors.New("expecting at least 1 shard")
+	}
+	s := &Sharded{shards: make([]*Election, shards)}
+	for k := range s.shards {
+		e, err := New(n, opts...)
+		if err != nil {
+			return nil, err
+		}
+		s.shards[k] = e
+	}
+	return s, nil
+}
+
+// NumShards returns the number of shards.
+func (s *Sharded) NumShards() int { return len(s.shards) }
+
+// Shard returns the election of shard k.
+// A shard must not be used by several goroutines at the same time.
+func (s *Sharded) Shard(k int) *Election { return s.shards[k] }
+
+// Result returns a snapshot of the election, merging the shards.
+// It must not be called while a shard is in use.
+// It returns ErrOverflow if the sum of the shards overflows.
+func (s *Sharded) Result() (Result, error) {
+	total := s.shards[0].Result().e
+	for _, shard := range s.shards[1:] {
+		// shards share their options, they are compatible
+		if err := total.merge(shard.Result().e); err != nil {
+			return Result{}, err
+		}
+	}
+	return Result{total}, nil
+}
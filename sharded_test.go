@@ -0,0 +1,44 @@
+package condorcet_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestSharded tallies the testcases in shards; run with -race.
+func TestSharded(t *testing.T) {
+	for _, tc := range testcases {
+		s, err := condorcet.NewSharded(4, tc.num, condorcet.Exact())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var wg sync.WaitGroup
+		for w := 0; w < s.NumShards(); w++ {
+			wg.Add(1)
+			go func(w int, shard *condorcet.Election) {
+				defer wg.Done()
+				for k, ballot := range tc.ballots {
+					if k%s.NumShards() == w {
+						shard.VoteN(uint(ballot[0]), ballot[1:]...)
+					}
+				}
+			}(w, s.Shard(w))
+		}
+		wg.Wait()
+
+		r, err := s.Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !r.Equal(result(t, tc.label, condorcet.Exact())) {
+			t.Errorf("%s: sharded tally differs from the sequential one", tc.label)
+		}
+	}
+
+	if _, err := condorcet.NewSharded(0, 3); err == nil {
+		t.Error("election without shard created")
+	}
+}
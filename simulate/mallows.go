@@ -0,0 +1,72 @@
+package simulate
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// Mallows generates ballots concentrated around a reference ranking (Mallows φ-model):
+// the probability of a ballot is proportional to φ^d,
+// where d is its Kendall tau distance to the reference,
+// i.e. the number of pairs of candidates it orders differently.
+//
+// The dispersion φ is in [0, 1]: ballots are the reference if φ is 0,
+// and uniformly random if φ is 1 (see ImpartialCulture).
+type Mallows struct {
+	reference []int
+	rnd       *rand.Rand
+	cumul     [][]float64 // cumulative probabilities of the insertion positions, see Ballot
+}
+
+// NewMallows returns a Mallows model around the reference ranking, of dispersion phi, drawing from rnd.
+// The reference must be a total order over its candidates.
+func NewMallows(reference []int, phi float64, rnd *rand.Rand) (*Mallows, error) {
+	if err := check(len(reference), rnd); err != nil {
+		return nil, err
+	}
+	if err := condorcet.Ballot(reference).Validate(len(reference)); err != nil {
+		return nil, err
+	}
+	if !(phi >= 0 && phi <= 1) {
+		return nil, errors.New("dispersion must be in [0, 1]")
+	}
+
+	// the i-th candidate of the reference is inserted j places before the end with a weight of φ^j
+	cumul := make([][]float64, len(reference))
+	for i := range cumul {
+		cumul[i] = make([]float64, i+1)
+		weight, total := 1.0, 0.0
+		for j := i; j >= 0; j-- {
+			total += weight
+			cumul[i][j] = total
+			weight *= phi
+		}
+		for j := range cumul[i] {
+			cumul[i][j] /= total
+		}
+	}
+
+	return &Mallows{append([]int(nil), reference...), rnd, cumul}, nil
+}
+
+// Ballot implements Generator.
+// It draws ballots with the repeated insertion model:
+// the candidates of the reference are inserted in order into the ballot,
+// each one at a random position.
+func (g *Mallows) Ballot() []int {
+	ballot := make([]int, 0, len(g.reference))
+	for i, c := range g.reference {
+		// the position is at least pos with probability cumul[i][pos]
+		u := g.rnd.Float64()
+		pos := i
+		for pos > 0 && u >= g.cumul[i][pos] {
+			pos--
+		}
+		ballot = append(ballot, 0)
+		copy(ballot[pos+1:], ballot[pos:])
+		ballot[pos] = c
+	}
+	return ballot
+}
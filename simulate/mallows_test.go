@@ -0,0 +1,54 @@
+package simulate_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/simulate"
+)
+
+// TestMallows checks the dispersion of the ballots around the reference.
+func TestMallows(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, phi := range []float64{-0.1, 1.5} {
+		if _, err := simulate.NewMallows([]int{0, 1, 2}, phi, rnd); err == nil {
+			t.Errorf("dispersion %v accepted", phi)
+		}
+	}
+	if _, err := simulate.NewMallows([]int{0, 1, 1}, 0.5, rnd); err == nil {
+		t.Error("invalid reference accepted")
+	}
+
+	// no dispersion: the reference only
+	g, _ := simulate.NewMallows([]int{2, 0, 3, 1}, 0, rnd)
+	for _, ballot := range simulate.Ballots(g, 100) {
+		if condorcet.Ballot(ballot).String() != condorcet.Ballot([]int{2, 0, 3, 1}).String() {
+			t.Fatalf("ballot %v differs from the reference", ballot)
+		}
+	}
+
+	// P(order) is proportional to φ^d, with d the number of inversions:
+	// 1, φ, φ, φ², φ², φ³ for 3 candidates, i.e. 8/21, 4/21, 4/21, 2/21, 2/21, 1/21 for φ = 1/2
+	g, _ = simulate.NewMallows([]int{0, 1, 2}, 0.5, rnd)
+	e, _ := condorcet.New(3, condorcet.Exact())
+	for _, ballot := range simulate.Ballots(g, 21000) {
+		e.Vote(ballot...)
+	}
+	for _, tc := range []struct {
+		ballot []int
+		want   int
+	}{
+		{[]int{0, 1, 2}, 8000},
+		{[]int{1, 0, 2}, 4000},
+		{[]int{0, 2, 1}, 4000},
+		{[]int{1, 2, 0}, 2000},
+		{[]int{2, 0, 1}, 2000},
+		{[]int{2, 1, 0}, 1000},
+	} {
+		code, _ := condorcet.EncodeBallot(tc.ballot)
+		if count := e.Result().Profile()[code]; count < tc.want*9/10 || count > tc.want*11/10 {
+			t.Errorf("ballot %v drawn %d times instead of about %d", tc.ballot, count, tc.want)
+		}
+	}
+}
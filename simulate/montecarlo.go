@@ -0,0 +1,191 @@
+package simulate
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// Model returns a generator of ballots over n candidates drawing from rnd:
+// a new electorate, e.g. with new candidate positions in a spatial model.
+type Model func(n int, rnd *rand.Rand) (Generator, error)
+
+// Experiment configures a Monte Carlo experiment:
+// many synthetic elections whose ballots are drawn from the same model.
+type Experiment struct {
+	Model      Model
+	Candidates int                         // number of candidates of each election
+	Voters     int                         // number of ballots of each election
+	Elections  int                         // number of elections
+	Methods    map[string]condorcet.Method // completion methods to compare, e.g. condorcet.Methods(false)
+	Options    []condorcet.Option          // options of the elections, e.g. condorcet.Exact for condorcet.Methods(true)
+	Workers    int                         // number of concurrent goroutines, 0 for GOMAXPROCS
+	Seed       int64                       // seed of the random sources
+}
+
+// Report is the outcome of an experiment.
+// Counts are numbers of elections (see Rate).
+type Report struct {
+	Elections int
+
+	Winners   int // elections with a Condorcet winner
+	CoWinners int // elections whose top candidates tie (see condorcet.Result.Winners)
+	Cycles    int // elections with a majority cycle among the top candidates
+
+	// Agreement[a][b] is the number of elections where methods a and b have the same unique winner.
+	// Agreement[a][a] is the number of elections where method a has a unique winner.
+	Agreement map[string]map[string]int
+}
+
+// Rate returns the frequency of a count of elections.
+func (r Report) Rate(count int) float64 {
+	if r.Elections == 0 {
+		return 0
+	}
+	return float64(count) / float64(r.Elections)
+}
+
+// add adds the counts of o to the report.
+func (r *Report) add(o Report) {
+	r.Elections += o.Elections
+	r.Winners += o.Winners
+	r.CoWinners += o.CoWinners
+	r.Cycles += o.Cycles
+	for a, row := range o.Agreement {
+		for b, count := range row {
+			r.Agreement[a][b] += count
+		}
+	}
+}
+
+// newReport returns an empty report of the methods.
+func newReport(methods map[string]condorcet.Method) Report {
+	r := Report{Agreement: make(map[string]map[string]int, len(methods))}
+	for a := range methods {
+		r.Agreement[a] = make(map[string]int, len(methods))
+	}
+	return r
+}
+
+// Run runs the experiment, in parallel.
+//
+// Each election draws from its own random source, seeded from the seed of the experiment,
+// so that the report does not depend on the number of workers.
+func Run(exp Experiment) (Report, error) {
+	if exp.Model == nil {
+		return Report{}, errors.New("missing model")
+	}
+	if exp.Voters < 1 {
+		return Report{}, errors.New("expecting at least 1 voter")
+	}
+	if exp.Elections < 0 {
+		return Report{}, errors.New("negative number of elections")
+	}
+	workers := exp.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	names := make([]string, 0, len(exp.Methods))
+	for name := range exp.Methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	seeds := make(chan int64)
+	go func() {
+		defer close(seeds)
+		rnd := rand.New(rand.NewSource(exp.Seed))
+		for k := 0; k < exp.Elections; k++ {
+			seeds <- rnd.Int63()
+		}
+	}()
+
+	var (
+		mu       sync.Mutex // protects report and firstErr
+		wg       sync.WaitGroup
+		report   = newReport(exp.Methods)
+		firstErr error
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			partial := newReport(exp.Methods)
+			var err error
+			for seed := range seeds {
+				if err == nil {
+					err = exp.run(&partial, names, rand.New(rand.NewSource(seed)))
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.add(partial)
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return Report{}, firstErr
+	}
+	return report, nil
+}
+
+// run runs an election of the experiment and adds its outcome to the report.
+// names are the names of the methods, in increasing order.
+func (exp Experiment) run(report *Report, names []string, rnd *rand.Rand) error {
+	g, err := exp.Model(exp.Candidates, rnd)
+	if err != nil {
+		return err
+	}
+	e, err := condorcet.New(exp.Candidates, exp.Options...)
+	if err != nil {
+		return err
+	}
+	for k := 0; k < exp.Voters; k++ {
+		if err := e.VoteE(g.Ballot()...); err != nil {
+			return err
+		}
+	}
+	r := e.Result()
+
+	report.Elections++
+	if _, exist := r.Winner(); exist {
+		report.Winners++
+	} else if r.Winners() != nil {
+		report.CoWinners++
+	} else {
+		report.Cycles++
+	}
+
+	winners := make([]int, len(names))
+	for k, name := range names {
+		w, unique, err := exp.Methods[name](r)
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		winners[k] = -1
+		if unique {
+			winners[k] = w
+		}
+	}
+	for i, a := range names {
+		for j, b := range names[:i+1] {
+			if winners[i] >= 0 && winners[i] == winners[j] {
+				report.Agreement[a][b]++
+				if a != b {
+					report.Agreement[b][a]++
+				}
+			}
+		}
+	}
+	return nil
+}
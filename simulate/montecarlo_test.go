@@ -0,0 +1,66 @@
+package simulate_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/simulate"
+)
+
+// impartialCulture is the model of the impartial culture.
+func impartialCulture(n int, rnd *rand.Rand) (simulate.Generator, error) {
+	return simulate.NewImpartialCulture(n, rnd)
+}
+
+// TestRun checks the frequency of Condorcet paradoxes in an impartial culture.
+func TestRun(t *testing.T) {
+	exp := simulate.Experiment{
+		Model:      impartialCulture,
+		Candidates: 3,
+		Voters:     101,
+		Elections:  2000,
+		Methods:    condorcet.Methods(false),
+		Seed:       1,
+	}
+	report, err := simulate.Run(exp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// about 8.8% of paradoxes with many voters, no tie with an odd number of voters
+	if report.Elections != 2000 || report.CoWinners != 0 || report.Winners+report.Cycles != 2000 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if rate := report.Rate(report.Cycles); rate < 0.06 || rate > 0.12 {
+		t.Errorf("%.3f of paradoxes", rate)
+	}
+
+	// Condorcet methods agree when there is a Condorcet winner
+	for a, row := range report.Agreement {
+		for b, count := range row {
+			if count < report.Winners || count > row[a] {
+				t.Errorf("%s and %s agree %d times", a, b, count)
+			}
+		}
+	}
+
+	// the report does not depend on the number of workers
+	exp.Workers = 1
+	if sequential, _ := simulate.Run(exp); !reflect.DeepEqual(sequential, report) {
+		t.Errorf("report %+v instead of %+v", sequential, report)
+	}
+
+	exp.Model = func(n int, rnd *rand.Rand) (simulate.Generator, error) {
+		return simulate.NewSinglePeaked(rnd.Perm(n), rnd)
+	}
+	if report, _ = simulate.Run(exp); report.Cycles != 0 {
+		t.Errorf("%d paradoxes with single-peaked ballots", report.Cycles)
+	}
+
+	exp.Candidates = 1
+	if _, err := simulate.Run(exp); err == nil {
+		t.Error("invalid model accepted")
+	}
+}
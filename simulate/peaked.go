@@ -0,0 +1,51 @@
+package simulate
+
+import (
+	"math/rand"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// SinglePeaked generates single-peaked ballots over an axis of the candidates,
+// e.g. from left to right:
+// each voter prefers a peak and ranks the candidates lower the farther they are from it on the axis,
+// on either side.
+// Elections with single-peaked ballots and an odd number of voters always have a Condorcet winner:
+// the peak of the median voter (median voter theorem).
+//
+// Ballots are drawn uniformly among the single-peaked orders.
+type SinglePeaked struct {
+	axis []int
+	rnd  *rand.Rand
+}
+
+// NewSinglePeaked returns a single-peaked model over the axis, drawing from rnd.
+// The axis must be a total order over its candidates.
+func NewSinglePeaked(axis []int, rnd *rand.Rand) (*SinglePeaked, error) {
+	if err := check(len(axis), rnd); err != nil {
+		return nil, err
+	}
+	if err := condorcet.Ballot(axis).Validate(len(axis)); err != nil {
+		return nil, err
+	}
+	return &SinglePeaked{append([]int(nil), axis...), rnd}, nil
+}
+
+// Ballot implements Generator.
+// The last candidate is at an end of the axis, the previous one at an end of the rest, and so on,
+// each time the left or the right end with equal probability.
+func (g *SinglePeaked) Ballot() []int {
+	ballot := make([]int, len(g.axis))
+	left, right := 0, len(g.axis)-1
+	for k := len(ballot) - 1; k > 0; k-- {
+		if g.rnd.Intn(2) == 0 {
+			ballot[k] = g.axis[left]
+			left++
+		} else {
+			ballot[k] = g.axis[right]
+			right--
+		}
+	}
+	ballot[0] = g.axis[left]
+	return ballot
+}
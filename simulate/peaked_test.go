@@ -0,0 +1,47 @@
+package simulate_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/simulate"
+)
+
+// TestSinglePeaked checks that the ballots are single-peaked and that the median voter wins.
+func TestSinglePeaked(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	if _, err := simulate.NewSinglePeaked([]int{0, 2}, rnd); err == nil {
+		t.Error("invalid axis accepted")
+	}
+
+	axis := []int{3, 0, 4, 1, 2}
+	pos := make([]int, len(axis))
+	for k, c := range axis {
+		pos[c] = k
+	}
+	g, err := simulate.NewSinglePeaked(axis, rnd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, _ := condorcet.New(len(axis))
+	for _, ballot := range simulate.Ballots(g, 999) {
+		// the top candidates of the ballot are an interval of the axis
+		left, right := pos[ballot[0]], pos[ballot[0]]
+		for _, c := range ballot[1:] {
+			switch pos[c] {
+			case left - 1:
+				left--
+			case right + 1:
+				right++
+			default:
+				t.Fatalf("ballot %v is not single-peaked", ballot)
+			}
+		}
+		e.Vote(ballot...)
+	}
+	if _, exist := e.Result().Winner(); !exist {
+		t.Error("no Condorcet winner")
+	}
+}
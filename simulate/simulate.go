@@ -0,0 +1,56 @@
+// Package simulate generates synthetic ballots from statistical models of the voters,
+// e.g. for benchmarks, and runs statistical experiments on Condorcet elections (see Run).
+//
+// Generators draw from a math/rand source, so that experiments are reproducible.
+// Like the source, they are not safe for concurrent use.
+package simulate
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// Generator generates random ballots:
+// total orders over the candidates, starting with the prefered one (see condorcet.Election.Vote).
+type Generator interface {
+	Ballot() []int
+}
+
+// Ballots returns count ballots of the generator.
+func Ballots(g Generator, count int) [][]int {
+	ballots := make([][]int, count)
+	for k := range ballots {
+		ballots[k] = g.Ballot()
+	}
+	return ballots
+}
+
+// check checks the parameters shared by the generators.
+func check(n int, rnd *rand.Rand) error {
+	if n < 2 {
+		return errors.New("expecting at least 2 candidates")
+	}
+	if rnd == nil {
+		return errors.New("missing random source")
+	}
+	return nil
+}
+
+// ImpartialCulture generates uniformly random total orders:
+// every order over the candidates is equally likely, independently of the other ballots.
+// It is the usual baseline of the frequency of Condorcet paradoxes.
+type ImpartialCulture struct {
+	n   int
+	rnd *rand.Rand
+}
+
+// NewImpartialCulture returns an impartial culture of n candidates drawing from rnd.
+func NewImpartialCulture(n int, rnd *rand.Rand) (*ImpartialCulture, error) {
+	if err := check(n, rnd); err != nil {
+		return nil, err
+	}
+	return &ImpartialCulture{n, rnd}, nil
+}
+
+// Ballot implements Generator.
+func (g *ImpartialCulture) Ballot() []int { return g.rnd.Perm(g.n) }
@@ -0,0 +1,35 @@
+package simulate_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/simulate"
+)
+
+// TestImpartialCulture checks that every order is about as frequent.
+func TestImpartialCulture(t *testing.T) {
+	if _, err := simulate.NewImpartialCulture(1, rand.New(rand.NewSource(1))); err == nil {
+		t.Error("single candidate accepted")
+	}
+	if _, err := simulate.NewImpartialCulture(3, nil); err == nil {
+		t.Error("missing random source accepted")
+	}
+
+	g, err := simulate.NewImpartialCulture(3, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, _ := condorcet.New(3, condorcet.Exact())
+	for _, ballot := range simulate.Ballots(g, 6000) {
+		if !e.Vote(ballot...) {
+			t.Fatalf("invalid ballot %v", ballot)
+		}
+	}
+	for code, count := range e.Result().Profile() {
+		if count < 850 || count > 1150 {
+			t.Errorf("order %d drawn %d times out of 6000", code, count)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package simulate
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+)
+
+// Spatial generates ballots from a spatial (Euclidean) model:
+// candidates and voters are points of a space of d dimensions,
+// e.g. positions on d political issues,
+// and every voter ranks the candidates from the nearest to the farthest.
+//
+// Candidates are placed once, when the model is created,
+// and each ballot is cast by a new voter.
+// Points are uniformly distributed in the unit hypercube.
+type Spatial struct {
+	candidates [][]float64
+	rnd        *rand.Rand
+}
+
+// NewSpatial returns a spatial model of n candidates in dim dimensions, drawing from rnd.
+func NewSpatial(n, dim int, rnd *rand.Rand) (*Spatial, error) {
+	if err := check(n, rnd); err != nil {
+		return nil, err
+	}
+	if dim < 1 {
+		return nil, errors.New("expecting at least 1 dimension")
+	}
+
+	g := &Spatial{candidates: make([][]float64, n), rnd: rnd}
+	for c := range g.candidates {
+		g.candidates[c] = g.point(dim)
+	}
+	return g, nil
+}
+
+// point returns a random point of the unit hypercube.
+func (g *Spatial) point(dim int) []float64 {
+	p := make([]float64, dim)
+	for k := range p {
+		p[k] = g.rnd.Float64()
+	}
+	return p
+}
+
+// Candidate returns the position of the candidate.
+func (g *Spatial) Candidate(c int) []float64 { return append([]float64(nil), g.candidates[c]...) }
+
+// Ballot implements Generator.
+// Candidates at the same distance are ranked in increasing order of index.
+func (g *Spatial) Ballot() []int { return g.Rank(g.point(len(g.candidates[0]))) }
+
+// Rank returns the ballot of a voter at the given position.
+func (g *Spatial) Rank(voter []float64) []int {
+	dist := make([]float64, len(g.candidates))
+	for c, p := range g.candidates {
+		for k, x := range p {
+			dist[c] += (x - voter[k]) * (x - voter[k])
+		}
+	}
+
+	ballot := make([]int, len(g.candidates))
+	for c := range ballot {
+		ballot[c] = c
+	}
+	sort.SliceStable(ballot, func(a, b int) bool { return dist[ballot[a]] < dist[ballot[b]] })
+	return ballot
+}
@@ -0,0 +1,39 @@
+package simulate_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/simulate"
+)
+
+// TestSpatial checks that voters rank the candidates by distance.
+func TestSpatial(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	if _, err := simulate.NewSpatial(3, 0, rnd); err == nil {
+		t.Error("space without dimension accepted")
+	}
+
+	g, err := simulate.NewSpatial(5, 2, rnd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for c := 0; c < 5; c++ {
+		if ballot := g.Rank(g.Candidate(c)); ballot[0] != c {
+			t.Errorf("voter at the position of %d ranks %v", c, ballot)
+		}
+	}
+
+	// in one dimension, the candidate nearest to the median voter is the Condorcet winner
+	g, _ = simulate.NewSpatial(5, 1, rnd)
+	e, _ := condorcet.New(5)
+	for _, ballot := range simulate.Ballots(g, 1001) {
+		if !e.Vote(ballot...) {
+			t.Fatalf("invalid ballot %v", ballot)
+		}
+	}
+	if _, exist := e.Result().Winner(); !exist {
+		t.Error("no Condorcet winner in one dimension")
+	}
+}
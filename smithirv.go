@@ -0,0 +1,43 @@
+package condorcet
+
+// SmithIRV returns the outcome of the Smith//IRV method:
+// the candidates outside the Smith set are eliminated (see Smith),
+// then instant-runoff voting runs among the remaining candidates.
+// At each round, the score of a candidate is the number of ballots
+// ranking it first among the running candidates.
+//
+// It returns the winner, false if the last candidates tie,
+// and the instant-runoff rounds.
+// The method requires the ballots: it returns ErrNoProfile if the election
+// does not store its profile (see Exact).
+// Candidates excluded by the eligibility hook do not run.
+// If "none of the above" is among the last candidates, there is no valid winner (see NoneOfTheAbove).
+func (r Result) SmithIRV() (w int, unique bool, rounds []Round, err error) {
+	if r.e.p == nil {
+		return 0, false, nil, ErrNoProfile
+	}
+
+	var winners []int
+	r.e.do(PhaseSmithIRV, func() { winners, rounds = r.eliminate(r.Smith(), r.firstPreferences) })
+	w, unique = r.elect(winners)
+	return w, unique, rounds, nil
+}
+
+// firstPreferences returns the number of ballots of the profile
+// ranking each running candidate first among the running candidates.
+func (r Result) firstPreferences(running []bool) []int {
+	scores := make([]int, r.e.num())
+	for code, count := range r.e.p {
+		if count == 0 {
+			continue
+		}
+		ballot, _ := DecodeBallot(uint64(code), r.e.num())
+		for _, c := range ballot {
+			if running[c] {
+				scores[c] += count
+				break
+			}
+		}
+	}
+	return scores
+}
@@ -0,0 +1,73 @@
+package condorcet_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_SmithIRV checks the Smith//IRV method on the testcases.
+func TestResult_SmithIRV(t *testing.T) {
+	testcases := []struct {
+		label      string
+		winner     int
+		unique     bool
+		eliminated [][]int
+	}{
+		{"Condorcet's example", 2, true, nil},
+		{"4 candidates", 3, true, nil},
+		{"paradoxe", 0, true, [][]int{{2}, {1}}}, // first preferences are 23, 19 and 18
+	}
+
+	for _, tc := range testcases {
+		w, unique, rounds, err := result(t, tc.label, condorcet.Exact()).SmithIRV()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.label, err)
+		}
+		if w != tc.winner || unique != tc.unique {
+			t.Errorf("%s: winner (%d, %v) instead of (%d, %v)", tc.label, w, unique, tc.winner, tc.unique)
+		}
+		var eliminated [][]int
+		for _, round := range rounds {
+			eliminated = append(eliminated, round.Eliminated)
+		}
+		if !reflect.DeepEqual(eliminated, tc.eliminated) {
+			t.Errorf("%s: eliminated %v instead of %v", tc.label, eliminated, tc.eliminated)
+		}
+	}
+
+	if _, unique, _, _ := result(t, "no vote", condorcet.Exact()).SmithIRV(); unique {
+		t.Error("unique winner without vote")
+	}
+
+	// the profile is required
+	if _, _, _, err := result(t, "paradoxe").SmithIRV(); err != condorcet.ErrNoProfile {
+		t.Errorf("unexpected error without profile: %v", err)
+	}
+}
+
+// TestResult_SmithIRVParticipation checks the participation of the ballots in each round.
+func TestResult_SmithIRVParticipation(t *testing.T) {
+	_, _, rounds, _ := result(t, "paradoxe", condorcet.Exact()).SmithIRV()
+	var participation [][3]int
+	for _, round := range rounds {
+		p := round.Participation
+		if p == nil {
+			t.Fatal("participation missing with the ballots")
+		}
+		participation = append(participation, [3]int{p.Active, p.Exhausted, p.Transferred})
+	}
+	// the 18 ballots ranking 2 first are transferred
+	if want := [][3]int{{60, 0, 0}, {60, 0, 18}}; !reflect.DeepEqual(participation, want) {
+		t.Errorf("participation %v instead of %v", participation, want)
+	}
+
+	// without the ballots, the participation is unknown
+	_, _, rounds = result(t, "paradoxe").Baldwin()
+	for k, round := range rounds {
+		if round.Participation != nil {
+			t.Errorf("round %d: participation %+v without the ballots", k, *round.Participation)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package condorcet
+
+// Sparse makes the election store its sum matrix sparsely,
+// for elections with many candidates whose ballots rank only a few of them,
+// e.g. crowdsourced rankings (see AllowTruncation).
+// Memory and voting time then depend on the pairs of candidates ranked together by some ballot,
+// rather than on the square of the number of candidates.
+//
+// Results are the same as with the dense storage,
+// but some operations on the candidates still take a time and memory
+// proportional to the square of their number (see Remap and MergeCandidates).
+// Counters are ints, whatever the width set by TallyWidth.
+func Sparse() Option {
+	return func(e *Election) { e.sparse = true }
+}
+
+// sparseMatrix is a sum matrix storing, for each candidate i, the number ranked[i] of voters ranking it,
+// and for some pairs a correction c[j][i], such that m[i][j] = ranked[i] - c[j][i].
+//
+// If a ballot ranks i above j, or i and j equally, it counts in ranked[i] and in c[i][j]:
+// the ballot does not prefer j to i.
+// Otherwise, if it ranks i, it prefers i to j, ranked or not.
+// Pairs of candidates never ranked together by a ballot have no correction.
+type sparseMatrix struct {
+	ranked []int
+	c      map[[2]int]int
+}
+
+// newSparseMatrix returns an empty sparse matrix over n candidates.
+func newSparseMatrix(n int) *sparseMatrix {
+	return &sparseMatrix{make([]int, n), make(map[[2]int]int)}
+}
+
+func (m *sparseMatrix) at(i, j int) int {
+	if i == j {
+		return 0
+	}
+	return m.ranked[i] - m.c[[2]int{j, i}]
+}
+
+func (m *sparseMatrix) add(i, j, x int) { m.correct(j, i, -x) }
+func (m *sparseMatrix) set(i, j, x int) { m.correct(j, i, m.ranked[i]-x-m.c[[2]int{j, i}]) }
+
+func (m *sparseMatrix) clone() matrix {
+	cp := &sparseMatrix{append([]int(nil), m.ranked...), make(map[[2]int]int, len(m.c))}
+	for k, x := range m.c {
+		cp.c[k] = x
+	}
+	return cp
+}
+
+// correct adds x to the correction of pair (i, j), dropping null corrections.
+func (m *sparseMatrix) correct(i, j, x int) {
+	k := [2]int{i, j}
+	if m.c[k] += x; m.c[k] == 0 {
+		delete(m.c, k)
+	}
+}
+
+// addBallot counts the ballot count times.
+func (m *sparseMatrix) addBallot(ballot []int, count int) {
+	for k, i := range ballot {
+		m.ranked[i] += count
+		for _, j := range ballot[k+1:] {
+			m.correct(i, j, count)
+		}
+	}
+}
+
+// addRanked counts the ballot with ties count times.
+func (m *sparseMatrix) addRanked(groups [][]int, count int) {
+	for k, g := range groups {
+		for _, i := range g {
+			m.ranked[i] += count
+			for _, j := range g {
+				if j != i {
+					m.correct(i, j, count)
+				}
+			}
+			for _, next := range groups[k+1:] {
+				for _, j := range next {
+					m.correct(i, j, count)
+				}
+			}
+		}
+	}
+}
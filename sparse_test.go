@@ -0,0 +1,50 @@
+package condorcet_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestSparse compares the sparse and dense storages.
+func TestSparse(t *testing.T) {
+	for _, tc := range testcases {
+		if !result(t, tc.label, condorcet.Sparse()).Equal(result(t, tc.label)) {
+			t.Errorf("%s: sparse tally differs", tc.label)
+		}
+	}
+
+	// truncated ballots, with and without ties
+	rnd := rand.New(rand.NewSource(1))
+	sparse, _ := condorcet.New(6, condorcet.AllowTruncation(), condorcet.Sparse())
+	dense, _ := condorcet.New(6, condorcet.AllowTruncation())
+	for k := 0; k < 200; k++ {
+		ballot := rnd.Perm(6)[:1+rnd.Intn(6)]
+		if k%3 == 0 && len(ballot) > 2 {
+			groups := [][]int{ballot[:2], ballot[2:]}
+			sparse.VoteRanked(groups...)
+			dense.VoteRanked(groups...)
+			continue
+		}
+		sparse.Vote(ballot...)
+		dense.Vote(ballot...)
+	}
+	sparse.Unvote(1, 2, 3)
+	dense.Unvote(1, 2, 3)
+	if err := sparse.Remap([]int{5, 4, 3, 2, 1, 0}); err != nil {
+		t.Fatal(err)
+	}
+	dense.Remap([]int{5, 4, 3, 2, 1, 0})
+	if !sparse.Result().Equal(dense.Result()) {
+		t.Error("sparse tally differs")
+	}
+
+	// many candidates
+	e, _ := condorcet.New(100000, condorcet.AllowTruncation(), condorcet.Sparse())
+	e.Vote(7, 3, 99999)
+	e.Vote(3, 7)
+	if m, _ := e.Result().Matchup(3, 99999); m.ForA != 2 || m.ForB != 0 {
+		t.Errorf("unexpected matchup %+v", m)
+	}
+}
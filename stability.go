@@ -0,0 +1,47 @@
+package condorcet
+
+import "errors"
+
+// Stability returns, for each pair of adjacent groups of the ranking,
+// how many ballots must change to swap them: the number of voters who must reverse
+// their preference between the two groups for a candidate of the lower group
+// to beat a candidate of the upper group head-to-head.
+//
+// Item k is the stability of the boundary between groups k and k+1.
+// It is 0 when a candidate of the lower group already beats or ties
+// a candidate of the upper group, which can happen with completion methods.
+//
+// It extends the margin of victory of the winner to the whole published ranking.
+func (r Result) Stability(rk Ranking) ([]int, error) {
+	for _, group := range rk {
+		for _, c := range group {
+			if c < 0 || c >= r.e.num() {
+				return nil, errors.New("candidate out of range")
+			}
+		}
+	}
+	if len(rk) < 2 {
+		return nil, nil
+	}
+
+	stability := make([]int, len(rk)-1)
+	for k := range stability {
+		least := -1
+		for _, a := range rk[k] {
+			for _, b := range rk[k+1] {
+				margin := r.e.m.at(a, b) - r.e.m.at(b, a)
+
+				// each reversed ballot reduces the margin by 2
+				changes := 0
+				if margin > 0 {
+					changes = margin/2 + 1
+				}
+				if least < 0 || changes < least {
+					least = changes
+				}
+			}
+		}
+		stability[k] = least
+	}
+	return stability, nil
+}
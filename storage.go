@@ -0,0 +1,250 @@
+package condorcet
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+// matrix stores the sum matrix:
+// the number of voters preferring candidate i to candidate j, for i != j.
+// Entries never exceed the number of voters,
+// which is bounded by the limit of the counters (see overflows).
+type matrix interface {
+	at(i, j int) int
+	add(i, j, x int)
+	set(i, j, x int)
+	clone() matrix
+
+	// addBallot counts the valid ballot count times.
+	// Candidates missing from a truncated ballot are tied last.
+	addBallot(ballot []int, count int)
+}
+
+// TallyWidth sets the number of bits of the counters of the sum matrix: 32 or 64.
+// Narrow counters use less memory, e.g. on embedded devices,
+// but limit the number of voters to math.MaxUint32 (see ErrOverflow).
+//
+// The default is the size of an int, and 64 requires 64-bit ints.
+// Counts are reported as int, so wider counters are not offered:
+// ErrOverflow guarantees that the tally is exact.
+func TallyWidth(bits int) Option {
+	return func(e *Election) { e.width = bits }
+}
+
+// checkWidth checks the width of the counters.
+func (e *Election) checkWidth() error {
+	switch {
+	case e.width == 0:
+	case e.width != 32 && e.width != 64:
+		return errors.New("tally width must be 32 or 64 bits")
+	case e.width > strconv.IntSize:
+		return errors.New("tally width exceeds the size of an int")
+	}
+	return nil
+}
+
+// limit returns the largest number of voters of the election.
+func (e *Election) limit() uint {
+	if e.width == 32 {
+		return math.MaxUint32 & maxInt
+	}
+	return maxInt
+}
+
+// newMatrix returns an empty sum matrix with counters of the width of the election.
+//
+// If the election is sparse, so is the matrix (see Sparse).
+// Unless ballots may be truncated or have ties, every ballot prefers one candidate of each pair to the other,
+// so that m[i][j] + m[j][i] is the same for all pairs, and the triangular layout is used.
+func (e *Election) newMatrix() matrix {
+	n := e.num()
+	if e.sparse {
+		return newSparseMatrix(n)
+	}
+	if e.truncation || e.ties {
+		return &denseMatrix{n, e.newVector(n * n)}
+	}
+	return newTriMatrix(n, e.newVector(n*(n-1)/2+1))
+}
+
+// newVector returns size counters of the width of the election.
+func (e *Election) newVector(size int) vector {
+	if e.width == 32 {
+		return make(uint32Vector, size)
+	}
+	return make(intVector, size)
+}
+
+// densify switches the sum matrix to the dense layout,
+// before counting ballots with ties.
+func (e *Election) densify() {
+	if _, ok := e.m.(*triMatrix); !ok {
+		return
+	}
+	n := e.num()
+	dense := &denseMatrix{n, e.newVector(n * n)}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j {
+				dense.set(i, j, e.m.at(i, j))
+			}
+		}
+	}
+	e.m = dense
+}
+
+// denseMatrix stores all the entries of the sum matrix, in row major order.
+type denseMatrix struct {
+	n int
+	v vector
+}
+
+func (m *denseMatrix) at(i, j int) int { return m.v.get(m.n*i + j) }
+func (m *denseMatrix) add(i, j, x int) { m.v.add(m.n*i+j, x) }
+func (m *denseMatrix) set(i, j, x int) { m.v.set(m.n*i+j, x) }
+func (m *denseMatrix) clone() matrix   { return &denseMatrix{m.n, m.v.clone()} }
+
+func (m *denseMatrix) addBallot(ballot []int, count int) {
+	v, ok := m.v.(intVector)
+	switch {
+	case ok && len(ballot) == m.n:
+		for k, i := range ballot {
+			row := v[m.n*i : m.n*(i+1)]
+			for _, j := range ballot[k+1:] {
+				row[j] += count
+			}
+		}
+	case ok:
+		// i is prefered to every candidate but itself and the ones ranked above it
+		for k, i := range ballot {
+			row := v[m.n*i : m.n*(i+1)]
+			for j := range row {
+				row[j] += count
+			}
+			for _, j := range ballot[:k+1] {
+				row[j] -= count
+			}
+		}
+	default:
+		ranked := newBitset(m.n)
+		for _, i := range ballot {
+			ranked.add(i)
+		}
+		for k, i := range ballot {
+			for _, j := range ballot[k+1:] {
+				m.add(i, j, count)
+			}
+			for j := 0; j < m.n; j++ {
+				if !ranked.has(j) {
+					m.add(i, j, count)
+				}
+			}
+		}
+	}
+}
+
+// triMatrix stores the entries above the diagonal of a sum matrix whose pairs all sum to the same total,
+// in row major order, followed by m[1][0].
+// The total is m[0][1] + m[1][0], and an entry below the diagonal is the total minus its symmetric entry:
+// changing one of them only matters for pair (1, 0), the others follow from the total.
+// The layout halves the memory of the dense one.
+type triMatrix struct {
+	n   int
+	v   vector
+	off []int // offset of each row: entry (i, j) is at off[i] + j
+}
+
+// newTriMatrix returns a triangular matrix over n candidates storing its entries in v.
+func newTriMatrix(n int, v vector) *triMatrix {
+	m := &triMatrix{n: n, v: v, off: make([]int, n)}
+	for i := range m.off {
+		m.off[i] = i*n - i*(i+1)/2 - i - 1
+	}
+	return m
+}
+
+// index returns the index of entry (i, j) above the diagonal.
+func (m *triMatrix) index(i, j int) int { return m.off[i] + j }
+
+// total returns the sum of the entries of every pair.
+func (m *triMatrix) total() int { return m.v.get(0) + m.v.get(m.v.len()-1) }
+
+func (m *triMatrix) at(i, j int) int {
+	switch {
+	case i < j:
+		return m.v.get(m.index(i, j))
+	case i > j:
+		return m.total() - m.v.get(m.index(j, i))
+	}
+	return 0
+}
+
+func (m *triMatrix) add(i, j, x int) {
+	switch {
+	case i < j:
+		m.v.add(m.index(i, j), x)
+	case i == 1 && j == 0:
+		m.v.add(m.v.len()-1, x)
+	}
+}
+
+func (m *triMatrix) set(i, j, x int) {
+	switch {
+	case i < j:
+		m.v.set(m.index(i, j), x)
+	case i == 1 && j == 0:
+		m.v.set(m.v.len()-1, x)
+	}
+}
+
+func (m *triMatrix) clone() matrix { return &triMatrix{m.n, m.v.clone(), m.off} }
+
+// addBallot counts the ballot, which must rank all the candidates.
+func (m *triMatrix) addBallot(ballot []int, count int) {
+	v, ok := m.v.(intVector)
+	if !ok {
+		for k, i := range ballot {
+			for _, j := range ballot[k+1:] {
+				m.add(i, j, count)
+			}
+		}
+		return
+	}
+	for k, i := range ballot {
+		for _, j := range ballot[k+1:] {
+			if i < j {
+				v[m.off[i]+j] += count
+			} else if i == 1 && j == 0 {
+				v[len(v)-1] += count
+			}
+		}
+	}
+}
+
+// vector is a sequence of counters.
+type vector interface {
+	len() int
+	get(k int) int
+	add(k, x int)
+	set(k, x int)
+	clone() vector
+}
+
+// intVector is a vector of int counters.
+type intVector []int
+
+func (v intVector) len() int      { return len(v) }
+func (v intVector) get(k int) int { return v[k] }
+func (v intVector) add(k, x int)  { v[k] += x }
+func (v intVector) set(k, x int)  { v[k] = x }
+func (v intVector) clone() vector { return append(intVector(nil), v...) }
+
+// uint32Vector is a vector of uint32 counters.
+type uint32Vector []uint32
+
+func (v uint32Vector) len() int      { return len(v) }
+func (v uint32Vector) get(k int) int { return int(v[k]) }
+func (v uint32Vector) add(k, x int)  { v[k] = uint32(int(v[k]) + x) }
+func (v uint32Vector) set(k, x int)  { v[k] = uint32(x) }
+func (v uint32Vector) clone() vector { return append(uint32Vector(nil), v...) }
@@ -0,0 +1,80 @@
+package condorcet_test
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestTallyWidth checks that narrow counters give the same tally, within their limit.
+func TestTallyWidth(t *testing.T) {
+	for _, tc := range testcases {
+		if !result(t, tc.label, condorcet.TallyWidth(32)).Equal(result(t, tc.label)) {
+			t.Errorf("%s: 32-bit tally differs", tc.label)
+		}
+	}
+
+	e, _ := condorcet.New(3, condorcet.TallyWidth(32))
+	if !e.VoteN(math.MaxUint32, 0, 1, 2) {
+		t.Fatal("largest count refused")
+	}
+	if err := e.VoteE(0, 1, 2); err != condorcet.ErrOverflow {
+		t.Errorf("unexpected error %v", err)
+	}
+	if m, _ := e.Result().Matchup(0, 1); uint(m.ForA) != math.MaxUint32 {
+		t.Errorf("%d voters prefer 0 to 1", m.ForA)
+	}
+
+	if _, err := condorcet.New(3, condorcet.TallyWidth(16)); err == nil {
+		t.Error("16-bit counters accepted")
+	}
+}
+
+// TestElection_storage checks the tally when the layout of the sum matrix changes.
+func TestElection_storage(t *testing.T) {
+	// complete ballots, then a ballot with ties
+	e, _ := condorcet.New(3)
+	e.Vote(0, 1, 2)
+	e.VoteN(2, 2, 1, 0)
+	e.VoteRanked([]int{1, 2}, []int{0})
+	want := [][]int{{0, 1, 1}, {3, 0, 1}, {3, 2, 0}}
+	if m := e.Result().Matrix(); !reflect.DeepEqual(m, want) {
+		t.Errorf("matrix is %v instead of %v", m, want)
+	}
+
+	// merge of ballots with ties
+	complete, _ := condorcet.New(3)
+	complete.Vote(0, 1, 2)
+	complete.VoteN(2, 2, 1, 0)
+	tied, _ := condorcet.New(3)
+	tied.VoteRanked([]int{1, 2}, []int{0})
+	if err := complete.Merge(tied); err != nil {
+		t.Fatal(err)
+	}
+	if !complete.Result().Equal(e.Result()) {
+		t.Error("merged tally differs")
+	}
+
+	// the pairs of complete ballots are consistent
+	var restored condorcet.Election
+	data := `{"version":1,"candidates":3,"voters":1,"ballots":1,"pairwise":[[0,1,1],[0,0,1],[0,1,0]]}`
+	if err := json.Unmarshal([]byte(data), &restored); err == nil {
+		t.Error("inconsistent pairwise matrix restored")
+	}
+}
+
+// TestElection_truncatedStorage checks the tally of truncated ballots with both counter widths.
+func TestElection_truncatedStorage(t *testing.T) {
+	want := [][]int{{0, 1, 0, 1}, {2, 0, 2, 3}, {3, 1, 0, 3}, {0, 0, 0, 0}}
+	for _, opts := range [][]condorcet.Option{nil, {condorcet.TallyWidth(32)}} {
+		e, _ := condorcet.New(4, append(opts, condorcet.AllowTruncation())...)
+		e.VoteN(2, 1, 2)
+		e.Vote(2, 0, 1, 3)
+		if m := e.Result().Matrix(); !reflect.DeepEqual(m, want) {
+			t.Errorf("options %v: matrix is %v instead of %v", opts, m, want)
+		}
+	}
+}
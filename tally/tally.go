@@ -0,0 +1,194 @@
+// Package tally is the tallying layer of the layered API:
+// it sums ballots into a pairwise matrix, free of voter identity and result computation.
+//
+// The layered API splits the responsibilities of condorcet.Election in three packages:
+//   - collect gathers ballots: voter identity and storage,
+//   - tally sums ballots into a pairwise matrix,
+//   - outcome computes results from a tally.
+//
+// A Tally only holds the pairwise matrix and the number of voters:
+// unlike an Election, it keeps no ballot, journal, hook nor snapshot.
+// FromResult adapts a result of the current API to this layer,
+// and outcome.Result adapts a tally back to it.
+package tally
+
+import (
+	"errors"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// maxInt is the largest number of voters of a tally.
+const maxInt = int(^uint(0) >> 1)
+
+// Option configures a tally.
+type Option func(*Tally)
+
+// AllowTruncation allows ballots ranking some of the candidates only:
+// the candidates they do not rank are tied last (see condorcet.AllowTruncation).
+func AllowTruncation() Option {
+	return func(t *Tally) { t.truncation = true }
+}
+
+// NoneOfTheAbove adds the "none of the above" candidate,
+// numbered after the other candidates (see condorcet.NoneOfTheAbove).
+func NoneOfTheAbove() Option {
+	return func(t *Tally) { t.nota = true }
+}
+
+// Tally is the pairwise matrix of an election:
+// the number of voters preferring each candidate to each other.
+type Tally struct {
+	n          int // number of candidates, including "none of the above"
+	nota       bool
+	truncation bool
+	voters     int
+	m          []int // m[n*i+j] is the number of voters preferring i to j
+}
+
+// New returns an empty tally over n candidates.
+func New(n int, opts ...Option) (*Tally, error) {
+	if n < 2 {
+		return nil, errors.New("expecting at least 2 candidates")
+	}
+	t := &Tally{n: n}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.nota {
+		t.n++
+	}
+	t.m = make([]int, t.n*t.n)
+	return t, nil
+}
+
+// FromResult returns a tally holding the counts of a result of the current API.
+// The options must match the ones of the election.
+func FromResult(r condorcet.Result, opts ...Option) (*Tally, error) {
+	n := r.NumCandidates()
+	var probe Tally
+	for _, opt := range opts {
+		opt(&probe)
+	}
+	if probe.nota {
+		n--
+	}
+	t, err := New(n, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for i, row := range r.Matrix() {
+		copy(t.m[t.n*i:], row)
+	}
+	t.voters = r.NumVoters()
+	return t, nil
+}
+
+// Add counts a ballot count times, as groups of tied candidates from the preferred one.
+// Groups must not be empty and must cover all the candidates,
+// or some of them if truncated ballots are allowed (see AllowTruncation).
+// It returns false if the ballot is rejected, if count is zero or if the number of voters would overflow:
+// nothing is counted.
+func (t *Tally) Add(count uint, groups ...[]int) bool {
+	if count == 0 || count > uint(maxInt-t.voters) || !t.valid(groups) {
+		return false
+	}
+	c := int(count)
+
+	ranked := make([]bool, t.n)
+	for _, g := range groups {
+		for _, i := range g {
+			ranked[i] = true
+		}
+	}
+	for k, g := range groups {
+		for _, i := range g {
+			// i is preferred to the candidates of the next groups and to the unranked ones
+			for _, next := range groups[k+1:] {
+				for _, j := range next {
+					t.m[t.n*i+j] += c
+				}
+			}
+			for j, r := range ranked {
+				if !r {
+					t.m[t.n*i+j] += c
+				}
+			}
+		}
+	}
+	t.voters += c
+	return true
+}
+
+// valid reports whether the groups form a valid ballot.
+func (t *Tally) valid(groups [][]int) bool {
+	seen := make([]bool, t.n)
+	ranked := 0
+	for _, g := range groups {
+		if len(g) == 0 {
+			return false
+		}
+		for _, c := range g {
+			if c < 0 || c >= t.n || seen[c] {
+				return false
+			}
+			seen[c] = true
+			ranked++
+		}
+	}
+	return ranked > 0 && (t.truncation || ranked == t.n)
+}
+
+// Merge adds the counts of another tally over the same candidates.
+// It returns condorcet.ErrOverflow if the number of voters would overflow.
+func (t *Tally) Merge(o *Tally) error {
+	if o.n != t.n || o.nota != t.nota {
+		return errors.New("tallies over different candidates")
+	}
+	if o.truncation && !t.truncation {
+		return errors.New("cannot merge truncated ballots into a tally of total orders")
+	}
+	if o.voters > maxInt-t.voters {
+		return condorcet.ErrOverflow
+	}
+	for k, x := range o.m {
+		t.m[k] += x
+	}
+	t.voters += o.voters
+	return nil
+}
+
+// NumCandidates returns the number of candidates, including "none of the above".
+func (t *Tally) NumCandidates() int { return t.n }
+
+// NOTA returns the "none of the above" candidate, false if there is none (see NoneOfTheAbove).
+func (t *Tally) NOTA() (int, bool) {
+	if !t.nota {
+		return 0, false
+	}
+	return t.n - 1, true
+}
+
+// AllowsTruncation reports whether the tally accepts truncated ballots (see AllowTruncation).
+func (t *Tally) AllowsTruncation() bool { return t.truncation }
+
+// Support returns the number of voters preferring i to j.
+// It returns 0 if i and j are the same or unknown candidates.
+func (t *Tally) Support(i, j int) int {
+	if i == j || i < 0 || j < 0 || i >= t.n || j >= t.n {
+		return 0
+	}
+	return t.m[t.n*i+j]
+}
+
+// Matrix returns a copy of the pairwise matrix: entry [i][j] is the support of i against j.
+func (t *Tally) Matrix() [][]int {
+	m := make([][]int, t.n)
+	for i := range m {
+		m[i] = append([]int(nil), t.m[t.n*i:t.n*(i+1)]...)
+	}
+	return m
+}
+
+// NumVoters returns the number of voters.
+func (t *Tally) NumVoters() int { return t.voters }
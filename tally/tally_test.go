@@ -0,0 +1,89 @@
+package tally_test
+
+import (
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+	"github.com/batiazinga/condorcet/tally"
+)
+
+// TestTally_Add checks the counts of ballots with ties.
+func TestTally_Add(t *testing.T) {
+	tl, err := tally.New(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tl.Add(2, []int{0}, []int{1}, []int{2}) {
+		t.Fatal("total order rejected")
+	}
+	if !tl.Add(1, []int{1, 2}, []int{0}) {
+		t.Fatal("ballot with ties rejected")
+	}
+	if tl.Add(1, []int{0}, []int{0, 1, 2}) {
+		t.Error("invalid ballot accepted")
+	}
+	if tl.Add(0, []int{0}, []int{1}, []int{2}) {
+		t.Error("zero count accepted")
+	}
+
+	if n := tl.NumVoters(); n != 3 {
+		t.Errorf("%d voters instead of 3", n)
+	}
+	want := [3][3]int{
+		{0, 2, 2},
+		{1, 0, 2},
+		{1, 0, 0},
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if s := tl.Support(i, j); s != want[i][j] {
+				t.Errorf("support of %d against %d is %d instead of %d", i, j, s, want[i][j])
+			}
+		}
+	}
+}
+
+// TestTally_truncated checks that the options of the tally shape the ballots it accepts.
+func TestTally_truncated(t *testing.T) {
+	tl, _ := tally.New(3)
+	if tl.Add(1, []int{0}) {
+		t.Error("truncated ballot accepted")
+	}
+
+	tl, _ = tally.New(3, tally.AllowTruncation())
+	if !tl.Add(1, []int{0}) {
+		t.Fatal("truncated ballot rejected")
+	}
+	if tl.Support(0, 1) != 1 || tl.Support(1, 2) != 0 {
+		t.Errorf("unranked candidates are not tied last")
+	}
+}
+
+// TestTally_Merge checks the merge of tallies and the adapter of the current API.
+func TestTally_Merge(t *testing.T) {
+	e, _ := condorcet.New(3)
+	e.Vote(2, 0, 1)
+	wrapped, err := tally.FromResult(e.Result())
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Vote(0, 1, 2) // the tally does not follow the election
+
+	tl, _ := tally.New(3)
+	tl.Add(1, []int{0}, []int{1}, []int{2})
+	if err := tl.Merge(wrapped); err != nil {
+		t.Fatal(err)
+	}
+	if tl.NumVoters() != 2 || tl.Support(2, 1) != 1 || tl.Support(0, 1) != 2 {
+		t.Errorf("unexpected merged tally")
+	}
+
+	other, _ := tally.New(4)
+	if err := tl.Merge(other); err == nil {
+		t.Error("tallies over different candidates merged")
+	}
+	truncated, _ := tally.New(3, tally.AllowTruncation())
+	if err := tl.Merge(truncated); err == nil {
+		t.Error("truncated ballots merged into a tally of total orders")
+	}
+}
@@ -0,0 +1,70 @@
+package condorcet
+
+import (
+	"errors"
+	"time"
+)
+
+// Template is a reusable configuration of elections,
+// for organizations which run the same vote repeatedly.
+type Template struct {
+	// Candidates is the roster: candidate i is named Candidates[i].
+	Candidates []string
+
+	// Options are applied to every election created from the template.
+	Options []Option
+
+	// TieBreak is the rule deciding the outcome when there is no Condorcet winner,
+	// e.g. one of Methods. If nil, such an election has no winner.
+	TieBreak Method
+
+	// Quorum is the minimum number of voters for a result to be valid.
+	// Zero means no quorum.
+	Quorum int
+
+	// Window is how long voting stays open.
+	// Zero means no limit.
+	Window time.Duration
+}
+
+// New returns a new election configured by the template.
+func (t Template) New() (*Election, error) {
+	if len(t.Candidates) < 2 {
+		return nil, errors.New("expecting at least 2 candidates in the roster")
+	}
+	return NewNamed(t.Candidates, t.Options...)
+}
+
+// Winner returns the winner of the result according to the template:
+// the Condorcet winner if there is one, and otherwise the winner of the tie-break rule.
+// There is no winner if the quorum is not met,
+// if the tie-break rule ends in a tie or if "none of the above" wins (see NoneOfTheAbove).
+func (t Template) Winner(r Result) (int, bool, error) {
+	if !t.QuorumMet(r) {
+		return 0, false, nil
+	}
+	if w, exist := r.Winner(); exist || r.NOTAWins() || t.TieBreak == nil {
+		return w, exist, nil
+	}
+
+	w, unique, err := t.TieBreak(r)
+	if err != nil || !unique {
+		return 0, false, err
+	}
+	if nota, ok := r.e.NOTA(); ok && w == nota {
+		return 0, false, nil
+	}
+	return w, true, nil
+}
+
+// QuorumMet reports whether the result has enough voters.
+func (t Template) QuorumMet(r Result) bool { return r.NumVoters() >= t.Quorum }
+
+// Closes returns when voting closes for an election opened at the given time.
+// If the template has no window, it returns false.
+func (t Template) Closes(opened time.Time) (time.Time, bool) {
+	if t.Window <= 0 {
+		return time.Time{}, false
+	}
+	return opened.Add(t.Window), true
+}
@@ -0,0 +1,87 @@
+package condorcet_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestTemplate stamps out two independent elections from the same template.
+func TestTemplate(t *testing.T) {
+	tpl := condorcet.Template{
+		Candidates: []string{"Alice", "Bob", "Carol"},
+		Options:    []condorcet.Option{condorcet.Exact()},
+		Quorum:     2,
+		Window:     24 * time.Hour,
+	}
+
+	e1, err := tpl.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e2, _ := tpl.New()
+
+	e1.Vote(0, 1, 2)
+	if e2.NumVoters() != 0 {
+		t.Error("elections created from a template are not independent")
+	}
+	if tpl.QuorumMet(e1.Result()) {
+		t.Error("quorum met with a single voter")
+	}
+	e1.Vote(2, 1, 0)
+	if !tpl.QuorumMet(e1.Result()) {
+		t.Error("quorum not met with 2 voters")
+	}
+	if e1.Result().Profile() == nil {
+		t.Error("options of the template are not applied")
+	}
+
+	opened := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if closes, ok := tpl.Closes(opened); !ok || !closes.Equal(opened.Add(24*time.Hour)) {
+		t.Errorf("unexpected closing time (%v, %v)", closes, ok)
+	}
+
+	if _, err := (condorcet.Template{Candidates: []string{"Alone"}}).New(); err == nil {
+		t.Error("template with a single candidate accepted")
+	}
+}
+
+// TestTemplate_Winner checks the tie-break rule and the names of the roster.
+func TestTemplate_Winner(t *testing.T) {
+	tpl := condorcet.Template{
+		Candidates: []string{"Alice", "Bob", "Carol"},
+		TieBreak:   condorcet.Methods(false)["Schulze"],
+		Quorum:     1,
+	}
+	e, err := tpl.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := e.Name(1); name != "Bob" {
+		t.Errorf("candidate 1 is named %q instead of Bob", name)
+	}
+	if _, exist, err := tpl.Winner(e.Result()); exist || err != nil {
+		t.Errorf("winner without quorum: (%v, %v)", exist, err)
+	}
+
+	// cycle 0 > 1 > 2 > 0 decided by Schulze for Alice
+	e.VoteN(3, 0, 1, 2)
+	e.VoteN(2, 1, 2, 0)
+	e.VoteN(2, 2, 0, 1)
+	if _, exist := e.Result().Winner(); exist {
+		t.Fatal("unexpected Condorcet winner")
+	}
+	if w, exist, err := tpl.Winner(e.Result()); w != 0 || !exist || err != nil {
+		t.Errorf("unexpected tie-break winner (%d, %v, %v)", w, exist, err)
+	}
+
+	tpl.TieBreak = nil
+	if _, exist, _ := tpl.Winner(e.Result()); exist {
+		t.Error("winner without Condorcet winner nor tie-break rule")
+	}
+
+	if _, err := (condorcet.Template{Candidates: []string{"Alice", "Alice"}}).New(); err == nil {
+		t.Error("roster with duplicate names accepted")
+	}
+}
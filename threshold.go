@@ -0,0 +1,37 @@
+package condorcet
+
+import "errors"
+
+// Threshold is an outcome rule on top of the Condorcet victory:
+// the winner must also beat the status quo candidate by a supermajority,
+// i.e. by at least Num/Den of the voters expressing a preference between them.
+//
+// For instance, a two-thirds majority against "no change" is Threshold{StatusQuo: sq, Num: 2, Den: 3}.
+type Threshold struct {
+	StatusQuo int
+	Num, Den  int
+}
+
+// WinnerWithThreshold returns the Condorcet winner, like Winner,
+// and whether it meets the threshold.
+// If the status quo is the winner, the threshold is met.
+func (r Result) WinnerWithThreshold(t Threshold) (w int, exist, met bool, err error) {
+	if t.StatusQuo < 0 || t.StatusQuo >= r.e.num() {
+		return 0, false, false, errors.New("status quo out of range")
+	}
+	if t.Num < 0 || t.Den <= 0 || t.Num > t.Den {
+		return 0, false, false, errors.New("threshold must be a fraction in [0, 1]")
+	}
+
+	w, exist = r.Winner()
+	if !exist {
+		return 0, false, false, nil
+	}
+	if w == t.StatusQuo {
+		return w, true, true, nil
+	}
+
+	pro := r.e.m.at(w, t.StatusQuo)
+	con := r.e.m.at(t.StatusQuo, w)
+	return w, true, pro*t.Den >= t.Num*(pro+con), nil
+}
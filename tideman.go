@@ -0,0 +1,87 @@
+package condorcet
+
+import "sort"
+
+// Tideman returns the outcome of Tideman's Alternative method:
+// the running candidates are restricted to their Smith set (see Smith),
+// then the candidates ranked first by the fewest ballots among the running candidates
+// are eliminated, and so on until one candidate remains.
+//
+// It returns the winner, false if the last candidates tie,
+// and the rounds in the order of elimination.
+// Rounds restricting the candidates to their Smith set have no scores.
+// The method requires the ballots: it returns ErrNoProfile if the election
+// does not store its profile (see Exact).
+// Candidates excluded by the eligibility hook do not run.
+// If "none of the above" is among the last candidates, there is no valid winner (see NoneOfTheAbove).
+func (r Result) Tideman() (w int, unique bool, rounds []Round, err error) {
+	if r.e.p == nil {
+		return 0, false, nil, ErrNoProfile
+	}
+
+	var winners []int
+	r.e.do(PhaseTideman, func() { winners, rounds = r.tideman() })
+	w, unique = r.elect(winners)
+	return w, unique, rounds, nil
+}
+
+// tideman returns the remaining candidates of Tideman's Alternative method and its rounds.
+func (r Result) tideman() (winners []int, rounds []Round) {
+	running := make([]bool, r.e.num())
+	var previous []bool
+	winners = r.e.candidates()
+	for {
+		// restriction to the Smith set
+		smith := r.smith(winners)
+		if len(smith) < len(winners) {
+			var eliminated []int
+			for _, c := range winners {
+				if !contains(smith, c) {
+					eliminated = append(eliminated, c)
+				}
+			}
+			for c := range running {
+				running[c] = contains(winners, c)
+			}
+			rounds = append(rounds, r.newRound(nil, eliminated, running, previous))
+			previous = append(previous[:0], running...)
+			winners = smith
+		}
+		if len(winners) <= 1 {
+			return winners, rounds
+		}
+
+		// elimination of the instant-runoff losers
+		for c := range running {
+			running[c] = contains(winners, c)
+		}
+		scores := r.firstPreferences(running)
+		lowest := scores[winners[0]]
+		for _, c := range winners {
+			if scores[c] < lowest {
+				lowest = scores[c]
+			}
+		}
+		var eliminated, remaining []int
+		for _, c := range winners {
+			if scores[c] == lowest {
+				eliminated = append(eliminated, c)
+			} else {
+				remaining = append(remaining, c)
+			}
+		}
+		if len(remaining) == 0 {
+			rounds = append(rounds, r.newRound(scores, nil, running, previous))
+			return winners, rounds
+		}
+		rounds = append(rounds, r.newRound(scores, eliminated, running, previous))
+		previous = append(previous[:0], running...)
+		winners = remaining
+	}
+}
+
+// contains reports whether the sorted candidates contain c.
+func contains(candidates []int, c int) bool {
+	i := sort.SearchInts(candidates, c)
+	return i < len(candidates) && candidates[i] == c
+}
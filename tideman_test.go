@@ -0,0 +1,49 @@
+package condorcet_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_Tideman checks Tideman's Alternative method on the testcases.
+func TestResult_Tideman(t *testing.T) {
+	testcases := []struct {
+		label      string
+		winner     int
+		unique     bool
+		eliminated [][]int
+	}{
+		{"Condorcet's example", 2, true, [][]int{{0, 1}}},
+		{"4 candidates", 3, true, [][]int{{0, 1, 2}}},
+		{"paradoxe", 0, true, [][]int{{2}, {1}}},
+		{"Schulze's example", 0, true, [][]int{{3}, {1, 4}, {2}}},
+	}
+
+	for _, tc := range testcases {
+		w, unique, rounds, err := result(t, tc.label, condorcet.Exact()).Tideman()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.label, err)
+		}
+		if w != tc.winner || unique != tc.unique {
+			t.Errorf("%s: winner (%d, %v) instead of (%d, %v)", tc.label, w, unique, tc.winner, tc.unique)
+		}
+		var eliminated [][]int
+		for _, round := range rounds {
+			eliminated = append(eliminated, round.Eliminated)
+		}
+		if !reflect.DeepEqual(eliminated, tc.eliminated) {
+			t.Errorf("%s: eliminated %v instead of %v", tc.label, eliminated, tc.eliminated)
+		}
+	}
+
+	if _, unique, _, _ := result(t, "no vote", condorcet.Exact()).Tideman(); unique {
+		t.Error("unique winner without vote")
+	}
+
+	// the profile is required
+	if _, _, _, err := result(t, "paradoxe").Tideman(); err != condorcet.ErrNoProfile {
+		t.Errorf("unexpected error without profile: %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package condorcet
+
+import "time"
+
+// stampedBallot is a ballot and the time it was cast.
+type stampedBallot struct {
+	t      time.Time
+	ballot []int
+}
+
+// VoteAt registers the ballot like Vote and records the time it was cast,
+// so that it can be excluded from results as of an earlier time (see ResultAsOf).
+func (e *Election) VoteAt(t time.Time, ballot ...int) bool {
+	err := e.voteAt(t, ballot)
+	e.notify(VoteEvent{Ballot: ballot, Count: 1, Err: err})
+	return err == nil
+}
+
+// voteAt implements VoteAt.
+func (e *Election) voteAt(t time.Time, ballot []int) error {
+	ballot, err := e.check(ballot)
+	if err != nil {
+		return err
+	}
+	if err := e.record(t, ballot, 1); err != nil {
+		return err
+	}
+	e.cast(ballot, 1)
+	e.stamped = append(e.stamped, stampedBallot{t, append([]int(nil), ballot...)})
+	return nil
+}
+
+// ResultAsOf returns a snapshot of the election excluding the timestamped ballots
+// cast after the cutoff (see VoteAt).
+// Ballots registered without a timestamp are always included.
+func (e *Election) ResultAsOf(cutoff time.Time) Result {
+	r := e.Result()
+	for _, s := range r.e.stamped {
+		if s.t.After(cutoff) {
+			r.e.cast(s.ballot, -1)
+		}
+	}
+	r.e.stamped = nil
+	r.e.sync()
+	return r
+}
@@ -0,0 +1,39 @@
+package condorcet_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestElection_ResultAsOf checks that late ballots are excluded from results as of the cutoff.
+func TestElection_ResultAsOf(t *testing.T) {
+	cutoff := time.Date(2020, 6, 1, 20, 0, 0, 0, time.UTC)
+
+	e, _ := condorcet.New(3)
+	e.Vote(0, 1, 2) // no timestamp
+	e.VoteAt(cutoff.Add(-time.Hour), 0, 2, 1)
+	e.VoteAt(cutoff, 1, 2, 0)
+	e.VoteAt(cutoff.Add(time.Minute), 1, 0, 2)
+	e.VoteAt(cutoff.Add(time.Hour), 1, 2, 0)
+
+	if e.NumVoters() != 5 {
+		t.Errorf("%d voters instead of 5", e.NumVoters())
+	}
+	if w, exist := e.Result().Winner(); !exist || w != 1 {
+		t.Errorf("unexpected winner (%d, %v) with late ballots", w, exist)
+	}
+
+	r := e.ResultAsOf(cutoff)
+	if r.NumVoters() != 3 {
+		t.Errorf("%d voters as of the cutoff instead of 3", r.NumVoters())
+	}
+	if w, exist := r.Winner(); !exist || w != 0 {
+		t.Errorf("unexpected winner (%d, %v) as of the cutoff", w, exist)
+	}
+
+	if e.VoteAt(cutoff, 0, 0, 1) {
+		t.Error("invalid timestamped ballot accepted")
+	}
+}
@@ -0,0 +1,116 @@
+package condorcet
+
+import "time"
+
+// Unvote retracts a previously registered ballot, e.g. a spoiled ballot or a correction.
+// The ballot is sanitized and validated like Vote, then subtracted from the tally,
+// and the retraction is written to the journal (see Journal).
+//
+// It returns false if the ballot is invalid or was not registered.
+// If identical timestamped ballots were registered (see VoteAt), the latest ones are retracted first.
+// Without an exact profile (see Exact), only the sum matrix can tell:
+// the retraction is refused if it would make a count negative.
+func (e *Election) Unvote(ballot ...int) bool { return e.UnvoteN(1, ballot...) }
+
+// UnvoteN retracts count identical ballots, like Unvote.
+// It returns false if count is zero or does not fit an int.
+//
+// It also returns false once weighted ballots were registered (see VoteWeighted),
+// since the tally does not tell whether the ballot was one of them:
+// retract weighted ballots with UnvoteWeighted.
+func (e *Election) UnvoteN(count uint, ballot ...int) bool {
+	if count == 0 || count > maxInt || e.voters != e.ballots || e.ballots < int(count) {
+		return false
+	}
+	ballot, ok := e.accept(ballot)
+	if !ok || !e.registered(ballot, int(count)) {
+		return false
+	}
+	if e.record(time.Time{}, ballot, -int(count)) != nil {
+		return false
+	}
+
+	e.cast(ballot, -int(count))
+	e.unstamp(ballot, int(count))
+	e.publish()
+	return true
+}
+
+// UnvoteWeighted retracts a ballot registered with VoteWeighted and the same weight, like Unvote.
+// It returns false if the weight is zero or does not fit an int,
+// or if the election has no weighted ballot of at least this weight.
+func (e *Election) UnvoteWeighted(weight uint, ballot ...int) bool {
+	if weight == 0 || weight > maxInt {
+		return false
+	}
+	w := int(weight)
+	// a weighted ballot counts w voters and 1 ballot
+	if e.voters-e.ballots < w-1 {
+		return false
+	}
+	ballot, ok := e.accept(ballot)
+	if !ok || e.ballots < 1 || !e.registered(ballot, w) {
+		return false
+	}
+	if e.recordWeighted(time.Time{}, ballot, -w) != nil {
+		return false
+	}
+
+	e.castWeighted(ballot, -1, -w)
+	e.publish()
+	return true
+}
+
+// unstamp forgets the timestamps of the latest count timestamped ballots identical to the retracted ballot,
+// so that ResultAsOf does not retract them again.
+func (e *Election) unstamp(ballot []int, count int) {
+	for k := len(e.stamped) - 1; k >= 0 && count > 0; k-- {
+		if equalBallots(e.stamped[k].ballot, ballot) {
+			e.stamped = append(e.stamped[:k], e.stamped[k+1:]...)
+			count--
+		}
+	}
+}
+
+// equalBallots reports whether the ballots are identical.
+func equalBallots(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k := range a {
+		if a[k] != b[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// registered checks that the valid ballot can be retracted count times from the voters.
+func (e *Election) registered(ballot []int, count int) bool {
+	if !e.initialized() || e.voters < count {
+		return false
+	}
+
+	if e.exact {
+		code, _ := EncodeBallot(ballot)
+		return e.p[code] >= count
+	}
+
+	ranked := make([]bool, e.num())
+	for i, a := range ballot {
+		ranked[a] = true
+		for _, b := range ballot[i+1:] {
+			if e.m.at(a, b) < count {
+				return false
+			}
+		}
+	}
+	for _, a := range ballot {
+		for b := range ranked {
+			if !ranked[b] && e.m.at(a, b) < count {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package condorcet_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestElection_Unvote checks that retracted ballots leave the tally as if never cast.
+func TestElection_Unvote(t *testing.T) {
+	for _, opts := range [][]condorcet.Option{nil, {condorcet.Exact()}} {
+		e, _ := condorcet.New(3, opts...)
+		e.VoteN(2, 0, 1, 2)
+		e.Vote(2, 1, 0)
+		want := e.Result()
+
+		e.VoteN(3, 1, 0, 2)
+		if !e.Unvote(1, 0, 2) || !e.UnvoteN(2, 1, 0, 2) {
+			t.Fatal("registered ballot not retracted")
+		}
+		if !e.Result().Equal(want) || e.NumVoters() != 3 || e.NumBallots() != 3 {
+			t.Error("tally differs after retraction")
+		}
+
+		if e.UnvoteN(3, 0, 1, 2) {
+			t.Error("more ballots retracted than registered")
+		}
+		if e.UnvoteN(0, 0, 1, 2) || e.Unvote(0, 1) {
+			t.Error("invalid retraction accepted")
+		}
+	}
+
+	// the sum matrix cannot tell this ballot was not registered, but the profile can
+	e, _ := condorcet.New(3, condorcet.Exact())
+	e.Vote(0, 1, 2)
+	e.Vote(2, 1, 0)
+	if e.Unvote(1, 0, 2) {
+		t.Error("unregistered ballot retracted")
+	}
+}
+
+// TestElection_UnvoteTimestamped checks that a retracted timestamped ballot is not retracted again as of an earlier time.
+func TestElection_UnvoteTimestamped(t *testing.T) {
+	start := time.Date(2020, 3, 1, 8, 0, 0, 0, time.UTC)
+	e, _ := condorcet.New(3)
+	e.VoteAt(start, 0, 1, 2)
+	e.VoteAt(start.Add(time.Hour), 1, 2, 0)
+	e.VoteAt(start.Add(2*time.Hour), 1, 2, 0)
+	if !e.Unvote(1, 2, 0) {
+		t.Fatal("timestamped ballot not retracted")
+	}
+
+	r := e.ResultAsOf(start.Add(90 * time.Minute))
+	if r.NumVoters() != 2 || r.Pairwise(1, 0) != 1 {
+		t.Errorf("%d voters, %d prefer 1 to 0", r.NumVoters(), r.Pairwise(1, 0))
+	}
+	if err := r.Verify(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestElection_UnvoteWeighted checks that weighted ballots are retracted with their weight.
+func TestElection_UnvoteWeighted(t *testing.T) {
+	for _, opts := range [][]condorcet.Option{nil, {condorcet.Exact()}} {
+		e, _ := condorcet.New(3, opts...)
+		e.Vote(2, 1, 0)
+		want := e.Result()
+
+		e.VoteWeighted(3, 0, 1, 2)
+		if e.Unvote(0, 1, 2) {
+			t.Error("weighted ballot retracted as an unweighted one")
+		}
+		if e.UnvoteWeighted(4, 0, 1, 2) {
+			t.Error("weighted ballot retracted with a larger weight")
+		}
+		if !e.UnvoteWeighted(3, 0, 1, 2) {
+			t.Fatal("weighted ballot not retracted")
+		}
+		if !e.Result().Equal(want) || e.NumVoters() != 1 || e.NumBallots() != 1 {
+			t.Errorf("tally differs after retraction: %d voters, %d ballots", e.NumVoters(), e.NumBallots())
+		}
+
+		// without weighted ballot left, unweighted ballots can be retracted again
+		if !e.Unvote(2, 1, 0) {
+			t.Error("unweighted ballot not retracted")
+		}
+	}
+
+	// the retraction is replayed from the journal
+	var journal bytes.Buffer
+	e, _ := condorcet.New(3, condorcet.Journal(&journal))
+	e.VoteWeighted(3, 0, 1, 2)
+	e.UnvoteWeighted(3, 0, 1, 2)
+	replayed, _ := condorcet.New(3)
+	if _, err := replayed.Replay(&journal); err != nil {
+		t.Fatal(err)
+	}
+	if replayed.NumVoters() != 0 || replayed.NumBallots() != 0 {
+		t.Errorf("%d voters and %d ballots after replay", replayed.NumVoters(), replayed.NumBallots())
+	}
+}
@@ -0,0 +1,77 @@
+package condorcet
+
+import "fmt"
+
+// Verify checks the internal invariants of the result,
+// so that corrupted or hand-edited snapshots are detected before publication:
+//   - the sum matrix has one non-negative entry per pair of candidates and a zero diagonal,
+//   - every pair of candidates is compared by every voter,
+//     or by at most every voter if ballots may be truncated or have ties,
+//   - the profile, if stored, is consistent with the sum matrix,
+//   - the winner is consistent with the sum matrix.
+func (r Result) Verify() error {
+	n := r.e.num()
+
+	voters := r.e.voters
+	for i := 0; i < n; i++ {
+		if r.e.m.at(i, i) != 0 {
+			return fmt.Errorf("non-zero diagonal entry for candidate %d", i)
+		}
+		for j := 0; j < n; j++ {
+			if r.e.m.at(i, j) < 0 {
+				return fmt.Errorf("negative support of %d against %d", i, j)
+			}
+			if i >= j {
+				continue
+			}
+			compared := r.e.m.at(i, j) + r.e.m.at(j, i)
+			if compared > voters || (!r.e.truncation && !r.e.ties && compared != voters) {
+				return fmt.Errorf("%d and %d are not compared by the %d voters", i, j, voters)
+			}
+		}
+	}
+
+	if r.e.p != nil {
+		if uint64(len(r.e.p)) != factorial(n) {
+			return fmt.Errorf("profile has %d entries instead of %d", len(r.e.p), factorial(n))
+		}
+		derived := &Election{n: r.e.n}
+		derived.init()
+		for code, count := range r.e.p {
+			if count < 0 {
+				return fmt.Errorf("negative count for ballot %d", code)
+			}
+			ballot, _ := DecodeBallot(uint64(code), n)
+			derived.add(ballot, count)
+		}
+		for k := 0; k < n*n; k++ {
+			if i, j := k/n, k%n; i != j && derived.m.at(i, j) != r.e.m.at(i, j) {
+				return fmt.Errorf("profile is inconsistent with the sum matrix")
+			}
+		}
+	}
+
+	for _, x := range r.e.exclusions {
+		if x.Candidate < 0 || x.Candidate >= n {
+			return fmt.Errorf("excluded candidate %d out of range", x.Candidate)
+		}
+	}
+
+	// the winner beats every other eligible candidate, and only the winner does
+	w, exist := r.winner()
+	for c := 0; c < n; c++ {
+		if !r.e.eligible(c) {
+			continue
+		}
+		beatsAll := voters > 0
+		for o := 0; o < n; o++ {
+			if o != c && r.e.eligible(o) && r.e.m.at(c, o) <= r.e.m.at(o, c) {
+				beatsAll = false
+			}
+		}
+		if beatsAll != (exist && w == c) {
+			return fmt.Errorf("winner is inconsistent with the sum matrix")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package condorcet
+
+// WinnerUpdate is an event sent to the watchers of an election (see Watch).
+type WinnerUpdate struct {
+	Winner int  // meaningful only if Exist
+	Exist  bool // is there a Condorcet winner?
+	Voters int  // number of voters when the winner changed
+}
+
+// Watch returns a channel receiving an update whenever the Condorcet winner changes,
+// starting with the current winner, e.g. for live dashboards.
+// The winner changes as ballots arrive through Vote and its variants,
+// and through the other operations changing the tally, such as Accept, UnvoteN, Merge or Remap.
+//
+// The channel has a buffer of one update and keeps the latest one,
+// so that voting never waits for a slow watcher.
+// The winner is the one of the tally: the eligibility hook is not run (see SetEligibilityHook).
+// With an exact profile (see Exact), the sum matrix is rebuilt after each ballot.
+// Updates stop with Unwatch.
+func (e *Election) Watch() <-chan WinnerUpdate {
+	ch := make(chan WinnerUpdate, 1)
+	e.watchers = append(e.watchers, ch)
+	e.watched = e.current()
+	ch <- e.watched
+	return ch
+}
+
+// Unwatch stops the updates of a channel returned by Watch, and closes it.
+func (e *Election) Unwatch(ch <-chan WinnerUpdate) {
+	for k, w := range e.watchers {
+		if w == ch {
+			close(w)
+			e.watchers = append(e.watchers[:k], e.watchers[k+1:]...)
+			return
+		}
+	}
+}
+
+// current returns the current winner of the tally.
+func (e *Election) current() WinnerUpdate {
+	if !e.initialized() {
+		return WinnerUpdate{}
+	}
+	e.sync()
+	u := WinnerUpdate{Voters: e.voters}
+	u.Winner, u.Exist = Result{e}.winner()
+	if nota, ok := e.NOTA(); ok && u.Exist && u.Winner == nota {
+		u.Winner, u.Exist = 0, false
+	}
+	return u
+}
+
+// publish sends an update to the watchers if the winner changed.
+func (e *Election) publish() {
+	if len(e.watchers) == 0 {
+		return
+	}
+	u := e.current()
+	if u.Exist == e.watched.Exist && u.Winner == e.watched.Winner {
+		return
+	}
+	e.watched = u
+	for _, ch := range e.watchers {
+		// replace the pending update, if any
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- u
+	}
+}
@@ -0,0 +1,79 @@
+package condorcet_test
+
+import (
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestElection_Watch follows the winner as ballots arrive.
+func TestElection_Watch(t *testing.T) {
+	e, _ := condorcet.New(3)
+	ch := e.Watch()
+	if u := <-ch; u.Exist {
+		t.Errorf("winner %d without vote", u.Winner)
+	}
+
+	e.Vote(0, 1, 2)
+	if u := <-ch; !u.Exist || u.Winner != 0 || u.Voters != 1 {
+		t.Errorf("unexpected update %+v", u)
+	}
+
+	// 0 remains the winner: no update
+	e.Vote(0, 2, 1)
+	select {
+	case u := <-ch:
+		t.Errorf("unexpected update %+v", u)
+	default:
+	}
+
+	// only the latest update is kept
+	e.VoteN(2, 1, 2, 0) // tie between 0 and 1
+	e.Vote(2, 1, 0)     // 1 wins
+	if u := <-ch; !u.Exist || u.Winner != 1 || u.Voters != 5 {
+		t.Errorf("unexpected update %+v", u)
+	}
+
+	e.Unwatch(ch)
+	if _, ok := <-ch; ok {
+		t.Error("channel still open")
+	}
+	e.VoteN(5, 2, 0, 1) // no watcher anymore
+}
+
+// TestElection_WatchMutations follows the winner through the operations changing the tally.
+func TestElection_WatchMutations(t *testing.T) {
+	e, _ := condorcet.New(3)
+	ch := e.Watch()
+	<-ch
+	expect := func(op string, winner int, exist bool) {
+		t.Helper()
+		select {
+		case u := <-ch:
+			if u.Exist != exist || exist && u.Winner != winner {
+				t.Errorf("%s: unexpected update %+v", op, u)
+			}
+		default:
+			t.Errorf("%s: no update", op)
+		}
+	}
+
+	id, _ := e.VoteProvisional(0, 1, 2)
+	e.Accept(id)
+	expect("Accept", 0, true)
+
+	e.Unvote(0, 1, 2)
+	expect("Unvote", 0, false)
+
+	other, _ := condorcet.New(3)
+	other.Vote(1, 0, 2)
+	if err := e.Merge(other); err != nil {
+		t.Fatal(err)
+	}
+	expect("Merge", 1, true)
+
+	if err := e.Remap([]int{1, 0, 2}); err != nil {
+		t.Fatal(err)
+	}
+	expect("Remap", 0, true)
+}
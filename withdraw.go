@@ -0,0 +1,25 @@
+package condorcet
+
+import "sort"
+
+// WithdrawnReason is the reason of the exclusion of a withdrawn candidate (see Withdraw).
+const WithdrawnReason = "withdrawn"
+
+// Withdraw returns the result as if the candidates had withdrawn,
+// e.g. when a candidate drops out after ballots were cast:
+// outcomes are computed on the pairwise matrix restricted to the other candidates.
+//
+// Withdrawn candidates keep their index and are reported as exclusions (see Exclusions).
+// Unknown or already excluded candidates are ignored.
+// The result is left unchanged.
+func (r Result) Withdraw(candidates ...int) Result {
+	cp := r.e.clone()
+	cp.exclusions = append([]Exclusion(nil), r.e.exclusions...)
+	for _, c := range candidates {
+		if c >= 0 && c < cp.num() && cp.eligible(c) {
+			cp.exclusions = append(cp.exclusions, Exclusion{c, WithdrawnReason})
+		}
+	}
+	sort.Slice(cp.exclusions, func(i, j int) bool { return cp.exclusions[i].Candidate < cp.exclusions[j].Candidate })
+	return Result{cp}
+}
@@ -0,0 +1,37 @@
+package condorcet_test
+
+import (
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_Withdraw withdraws candidates from the paradox.
+func TestResult_Withdraw(t *testing.T) {
+	r := result(t, "paradoxe")
+
+	// 0 beats 1 by 33 to 27
+	w := r.Withdraw(2, 2, 5)
+	if winner, exist := w.Winner(); !exist || winner != 0 {
+		t.Errorf("winner is (%d, %v) instead of 0", winner, exist)
+	}
+	x := w.Exclusions()
+	if len(x) != 1 || x[0].Candidate != 2 || x[0].Reason != condorcet.WithdrawnReason {
+		t.Errorf("unexpected exclusions %+v", x)
+	}
+
+	// the last candidate wins
+	if winner, exist := w.Withdraw(0).Winner(); !exist || winner != 1 {
+		t.Errorf("winner is (%d, %v) instead of the last candidate", winner, exist)
+	}
+
+	// 1 beats 2 by 42 to 18
+	if winner, exist := r.Withdraw(0).Winner(); !exist || winner != 1 {
+		t.Errorf("winner is (%d, %v) instead of 1", winner, exist)
+	}
+
+	// the original result is unchanged
+	if _, exist := r.Winner(); exist || len(r.Exclusions()) != 0 {
+		t.Error("withdrawal changed the original result")
+	}
+}
@@ -0,0 +1,193 @@
+package condorcet
+
+import (
+	"context"
+	"sort"
+)
+
+// MaxYoungExactCandidates is the largest number of candidates
+// of an election whose Young scores are computed exactly (see YoungScores).
+const MaxYoungExactCandidates = 5
+
+// YoungScores returns the Young score of each candidate:
+// the minimum number of ballots to remove for the candidate to become the Condorcet winner.
+//
+// The scores are exact if the election stores its profile (see Exact)
+// and has at most MaxYoungExactCandidates candidates.
+// A candidate who cannot become the Condorcet winner then has a score of -1.
+// Otherwise the scores are approximated from the sum matrix by the largest deficit
+// of the candidate plus one, since removing a ballot reduces a deficit by at most one.
+// The approximation is a lower bound of the exact score.
+//
+// Candidates excluded by the eligibility hook have a zero score.
+func (r Result) YoungScores() (scores []int, exact bool) {
+	scores, exact, _ = r.YoungScoresCtx(context.Background())
+	return scores, exact
+}
+
+// YoungScoresCtx is like YoungScores, but abandons the exact search when ctx is done,
+// e.g. to impose a timeout, and then returns the error of ctx.
+func (r Result) YoungScoresCtx(ctx context.Context) (scores []int, exact bool, err error) {
+	exact = r.e.p != nil && r.e.num() <= MaxYoungExactCandidates
+	scores = make([]int, r.e.num())
+	stop := stopper(ctx)
+	r.e.do(PhaseYoung, func() {
+		for _, c := range r.e.candidates() {
+			if exact {
+				scores[c] = r.young(c, stop)
+			} else {
+				scores[c] = r.youngBound(c)
+			}
+		}
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, exact, err
+	}
+	return scores, exact, nil
+}
+
+// youngBound returns a lower bound of the Young score of candidate c.
+func (r Result) youngBound(c int) int {
+	bound := 0
+	for _, j := range r.e.candidates() {
+		if j == c {
+			continue
+		}
+		if need := r.e.m.at(j, c) - r.e.m.at(c, j) + 1; need > bound {
+			bound = need
+		}
+	}
+	return bound
+}
+
+// youngGroup is the number of ballots of the profile
+// ranking the same set of opponents above a candidate.
+type youngGroup struct {
+	above uint // opponents ranked above the candidate, as a bit set
+	size  int  // number of opponents in the set
+	count int  // number of ballots
+}
+
+// young returns the exact Young score of candidate c, computed from the profile.
+//
+// Removing a ballot ranking opponent j above c reduces the deficit of c against j by one,
+// and removing a ballot ranking c above j increases it by one.
+// Only the set of opponents ranked above c matters, so ballots are grouped by this set.
+// The smallest number of removals is then searched by iterative deepening,
+// abandoned if stop is not nil and returns true.
+func (r Result) young(c int, stop func() bool) int {
+	var opponents []int
+	for _, j := range r.e.candidates() {
+		if j != c {
+			opponents = append(opponents, j)
+		}
+	}
+
+	// need[k]: gain required against opponents[k]
+	need := make([]int, len(opponents))
+	for k, j := range opponents {
+		need[k] = r.e.m.at(j, c) - r.e.m.at(c, j) + 1
+	}
+
+	// group the ballots
+	counts := make(map[uint]int)
+	for code, count := range r.e.p {
+		if count == 0 {
+			continue
+		}
+		ballot, _ := DecodeBallot(uint64(code), r.e.num())
+		var above uint
+		for _, j := range ballot {
+			if j == c {
+				break
+			}
+			for k, o := range opponents {
+				if o == j {
+					above |= 1 << uint(k)
+				}
+			}
+		}
+		counts[above] += count
+	}
+	var groups []youngGroup
+	for above, count := range counts {
+		g := youngGroup{above: above, count: count}
+		for k := range opponents {
+			if above&(1<<uint(k)) != 0 {
+				g.size++
+			}
+		}
+		groups = append(groups, g)
+	}
+	// most useful removals first
+	sort.Slice(groups, func(a, b int) bool {
+		if groups[a].size != groups[b].size {
+			return groups[a].size > groups[b].size
+		}
+		return groups[a].above < groups[b].above
+	})
+
+	// avail[g][k]: ballots of groups g and after ranking opponents[k] above c
+	avail := make([][]int, len(groups)+1)
+	avail[len(groups)] = make([]int, len(opponents))
+	for g := len(groups) - 1; g >= 0; g-- {
+		avail[g] = make([]int, len(opponents))
+		for k := range opponents {
+			avail[g][k] = avail[g+1][k]
+			if groups[g].above&(1<<uint(k)) != 0 {
+				avail[g][k] += groups[g].count
+			}
+		}
+	}
+
+	gain := make([]int, len(opponents))
+	for limit := r.youngBound(c); limit <= r.NumVoters(); limit++ {
+		if youngSearch(groups, avail, need, gain, 0, limit, stop) {
+			return limit
+		}
+		if stop != nil && stop() {
+			return -1
+		}
+	}
+	return -1
+}
+
+// youngSearch reports whether at most left ballots can be removed
+// from groups g and after so that every gain meets its need.
+// It returns false as soon as stop, if not nil, returns true.
+func youngSearch(groups []youngGroup, avail [][]int, need, gain []int, g, left int, stop func() bool) bool {
+	if stop != nil && stop() {
+		return false
+	}
+	for k := range need {
+		if gain[k]+min(left, avail[g][k]) < need[k] {
+			return false
+		}
+	}
+	if g == len(groups) {
+		return true
+	}
+
+	grp := groups[g]
+	for removed := min(left, grp.count); removed >= 0; removed-- {
+		for k := range gain {
+			if grp.above&(1<<uint(k)) != 0 {
+				gain[k] += removed
+			} else {
+				gain[k] -= removed
+			}
+		}
+		ok := youngSearch(groups, avail, need, gain, g+1, left-removed, stop)
+		for k := range gain {
+			if grp.above&(1<<uint(k)) != 0 {
+				gain[k] -= removed
+			} else {
+				gain[k] += removed
+			}
+		}
+		if ok || (stop != nil && stop()) {
+			return ok
+		}
+	}
+	return false
+}
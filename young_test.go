@@ -0,0 +1,47 @@
+package condorcet_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/batiazinga/condorcet"
+)
+
+// TestResult_YoungScores checks the exact and approximated Young scores.
+func TestResult_YoungScores(t *testing.T) {
+	testcases := []struct {
+		label  string
+		opts   []condorcet.Option
+		scores []int
+		exact  bool
+	}{
+		{"Condorcet's example", []condorcet.Option{condorcet.Exact()}, []int{15, 23, 0}, true},
+		{"paradoxe", []condorcet.Option{condorcet.Exact()}, []int{11, 7, 25}, true},
+		{"paradoxe", nil, []int{11, 7, 25}, false},
+		{"4 candidates", []condorcet.Option{condorcet.Exact()}, []int{37, 67, 17, 0}, true},
+		{"no vote", []condorcet.Option{condorcet.Exact()}, []int{1, 1, 1, 1, 1, 1}, false}, // too many candidates
+	}
+
+	for _, tc := range testcases {
+		scores, exact := result(t, tc.label, tc.opts...).YoungScores()
+		if !reflect.DeepEqual(scores, tc.scores) || exact != tc.exact {
+			t.Errorf("%s: scores (%v, %v) instead of (%v, %v)", tc.label, scores, exact, tc.scores, tc.exact)
+		}
+	}
+
+	// without vote, no candidate can become the Condorcet winner
+	e, _ := condorcet.New(3, condorcet.Exact())
+	if scores, _ := e.Result().YoungScores(); !reflect.DeepEqual(scores, []int{-1, -1, -1}) {
+		t.Errorf("unexpected scores without vote: %v", scores)
+	}
+}
+
+// TestResult_YoungScoresCtx checks that the exact search is abandoned when the context is done.
+func TestResult_YoungScoresCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := result(t, "4 candidates", condorcet.Exact()).YoungScoresCtx(ctx); err != context.Canceled {
+		t.Errorf("unexpected error %v", err)
+	}
+}